@@ -0,0 +1,299 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/teambition/swaggo/parser"
+	"github.com/teambition/swaggo/swagger"
+	"github.com/urfave/cli"
+	yaml "gopkg.in/yaml.v2"
+)
+
+var validateCommand = cli.Command{
+	Name:      "validate",
+	Usage:     "validate the swagger file against the Swagger 2.0 schema",
+	ArgsUsage: "<file>",
+	Action: func(c *cli.Context) error {
+		if c.NArg() != 1 {
+			return fmt.Errorf("validate need one swagger file")
+		}
+		doc, err := swagger.ReadFile(c.Args().First())
+		if err != nil {
+			return err
+		}
+		errs := swagger.Validate(doc)
+		for _, e := range errs {
+			fmt.Println(e)
+		}
+		if len(errs) != 0 {
+			return fmt.Errorf("%d validation error(s) in %s", len(errs), c.Args().First())
+		}
+		return nil
+	},
+}
+
+var diffCommand = cli.Command{
+	Name:  "diff",
+	Usage: "detect the breaking changes between two swagger files",
+	ArgsUsage: "<old file> <new file>\n" +
+		"   swaggo diff --revision <git revision> <file>",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "revision, r",
+			Usage: "compare the file in working tree with the one in this git revision",
+		},
+		cli.BoolFlag{
+			Name:  "json",
+			Usage: "print the report as json",
+		},
+	},
+	Action: func(c *cli.Context) (err error) {
+		var from, to interface{}
+		switch {
+		case c.String("revision") != "" && c.NArg() == 1:
+			if from, err = readFileAtRevision(c.String("revision"), c.Args().First()); err != nil {
+				return
+			}
+			to, err = swagger.ReadFile(c.Args().First())
+		case c.String("revision") == "" && c.NArg() == 2:
+			if from, err = swagger.ReadFile(c.Args().Get(0)); err != nil {
+				return
+			}
+			to, err = swagger.ReadFile(c.Args().Get(1))
+		default:
+			return fmt.Errorf("diff need two swagger files, or one with the git revision")
+		}
+		if err != nil {
+			return
+		}
+
+		changes := swagger.Diff(from, to)
+		breaking := swagger.HasBreaking(changes)
+		if c.Bool("json") {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			if changes == nil {
+				changes = []*swagger.Change{}
+			}
+			if err = enc.Encode(map[string]interface{}{
+				"breaking": breaking,
+				"changes":  changes,
+			}); err != nil {
+				return
+			}
+		} else {
+			for _, change := range changes {
+				fmt.Println(change)
+			}
+		}
+		if breaking {
+			return fmt.Errorf("breaking changes found")
+		}
+		return nil
+	},
+}
+
+var changelogCommand = cli.Command{
+	Name:  "changelog",
+	Usage: "generate the markdown changelog of the swagger file between two git revisions",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "from",
+			Usage: "the old git revision",
+		},
+		cli.StringFlag{
+			Name:  "to",
+			Value: "HEAD",
+			Usage: "the new git revision",
+		},
+		cli.StringFlag{
+			Name:  "file, f",
+			Value: "./swagger.json",
+			Usage: "the swagger file in the git repository",
+		},
+		cli.BoolFlag{
+			Name:  "generate, g",
+			Usage: "generate the swagger docs from the sources at the revisions instead of reading the committed swagger file, the repository must be in GOPATH",
+		},
+		cli.StringFlag{
+			Name:  "project, p",
+			Value: "./",
+			Usage: "where is the project, used with --generate",
+		},
+		cli.StringFlag{
+			Name:  "swagger, s",
+			Value: "./swagger.go",
+			Usage: "where is the swagger.go file, used with --generate",
+		},
+		cli.BoolFlag{
+			Name:  "dev, d",
+			Usage: "develop mode, used with --generate",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		from, to, filename := c.String("from"), c.String("to"), c.String("file")
+		if from == "" {
+			return fmt.Errorf("changelog need the old git revision by --from")
+		}
+		read := func(revision string) (interface{}, error) {
+			if c.Bool("generate") {
+				opt := &parser.Option{Dev: c.Bool("dev"), Type: "json"}
+				return generateAtRevision(revision, c.String("project"), c.String("swagger"), opt)
+			}
+			doc, err := readFileAtRevision(revision, filename)
+			if err != nil {
+				return nil, fmt.Errorf("%v, the committed swagger file may be missing or out of date, use --generate to generate it from the sources", err)
+			}
+			return doc, nil
+		}
+		fromDoc, err := read(from)
+		if err != nil {
+			return err
+		}
+		toDoc, err := read(to)
+		if err != nil {
+			return err
+		}
+		fmt.Print(swagger.Changelog(fmt.Sprintf("API Changes %s...%s", from, to), fromDoc, toDoc))
+		return nil
+	},
+}
+
+var mergeCommand = cli.Command{
+	Name:      "merge",
+	Usage:     "merge the swagger files of services into one gateway document",
+	ArgsUsage: "[<service name>=]<file>...",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "output, o",
+			Usage: "the merged swagger file (json or yaml by extension), print json to stdout if empty",
+		},
+		cli.StringFlag{
+			Name:  "title",
+			Value: "API Gateway",
+			Usage: "the title of merged swagger file",
+		},
+		cli.StringFlag{
+			Name:  "api-version",
+			Value: "1.0.0",
+			Usage: "the version of merged swagger file",
+		},
+		cli.StringFlag{
+			Name:  "base-path",
+			Usage: "the basePath of merged swagger file",
+		},
+		cli.StringSliceFlag{
+			Name:  "prefix",
+			Usage: "the path prefix of service like `users=/users`, default is the basePath of service",
+		},
+		cli.BoolFlag{
+			Name:  "tag-prefix",
+			Usage: "prefix the tags with the service names",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		if c.NArg() == 0 {
+			return fmt.Errorf("merge need one swagger file at least")
+		}
+		prefixes := map[string]string{}
+		for _, p := range c.StringSlice("prefix") {
+			kv := strings.SplitN(p, "=", 2)
+			if len(kv) != 2 {
+				return fmt.Errorf("prefix(%s) should be like `name=/prefix`", p)
+			}
+			prefixes[kv[0]] = kv[1]
+		}
+		services := []*swagger.Service{}
+		names := map[string]bool{}
+		for _, arg := range c.Args() {
+			name, filename := serviceName(arg)
+			if names[name] {
+				return fmt.Errorf("service(%s) is duplicated, name it by `name=file`", name)
+			}
+			names[name] = true
+			doc, err := swagger.ReadFile(filename)
+			if err != nil {
+				return err
+			}
+			services = append(services, &swagger.Service{Name: name, Prefix: prefixes[name], Doc: doc})
+		}
+
+		doc, conflicts := swagger.Merge(services, &swagger.MergeOption{
+			Title:     c.String("title"),
+			Version:   c.String("api-version"),
+			BasePath:  c.String("base-path"),
+			TagPrefix: c.Bool("tag-prefix"),
+		})
+		for _, conflict := range conflicts {
+			log.Println("[Warning]", conflict)
+		}
+		return writeDocument(c.String("output"), doc)
+	},
+}
+
+var bundleCommand = cli.Command{
+	Name:      "bundle",
+	Usage:     "inline the external references of local files into a self-contained swagger file",
+	ArgsUsage: "<file>",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "output, o",
+			Usage: "the bundled swagger file (json or yaml by extension), print json to stdout if empty",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		if c.NArg() != 1 {
+			return fmt.Errorf("bundle need one swagger file")
+		}
+		doc, err := swagger.Bundle(c.Args().First())
+		if err != nil {
+			return err
+		}
+		return writeDocument(c.String("output"), doc)
+	},
+}
+
+// writeDocument write the generic swagger doc to file by extension,
+// or print json to stdout if the filename is empty
+func writeDocument(filename string, doc interface{}) (err error) {
+	var data []byte
+	switch filepath.Ext(filename) {
+	case ".yaml", ".yml":
+		data, err = yaml.Marshal(doc)
+	default:
+		if data, err = json.MarshalIndent(doc, "", "  "); err == nil {
+			data = append(data, '\n')
+		}
+	}
+	if err != nil {
+		return
+	}
+	if filename == "" {
+		_, err = os.Stdout.Write(data)
+		return
+	}
+	return ioutil.WriteFile(filename, data, 0644)
+}
+
+// serviceName parse the argument `[name=]file`,
+// the default name is the filename without extension,
+// or the directory name if the filename is swagger.json/yaml
+func serviceName(arg string) (name, filename string) {
+	if kv := strings.SplitN(arg, "=", 2); len(kv) == 2 {
+		return kv[0], kv[1]
+	}
+	base := filepath.Base(arg)
+	name = strings.TrimSuffix(base, filepath.Ext(base))
+	if name == "swagger" {
+		if abs, err := filepath.Abs(arg); err == nil {
+			name = filepath.Base(filepath.Dir(abs))
+		}
+	}
+	return name, arg
+}
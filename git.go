@@ -0,0 +1,133 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/teambition/swaggo/parser"
+	"github.com/teambition/swaggo/swagger"
+)
+
+// git run the git command in the directory and return its output
+func git(dir string, args ...string) ([]byte, error) {
+	stderr := &bytes.Buffer{}
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Stderr = stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git %s error(%v) %s", strings.Join(args, " "), err, bytes.TrimSpace(stderr.Bytes()))
+	}
+	return out, nil
+}
+
+// gitShow read the content of file at the revision from the local git repository
+func gitShow(revision, filename string) ([]byte, error) {
+	abs, err := filepath.Abs(filename)
+	if err != nil {
+		return nil, err
+	}
+	dir, base := filepath.Split(abs)
+	if _, err = git(dir, "rev-parse", "--verify", "--quiet", revision+"^{commit}"); err != nil {
+		return nil, fmt.Errorf("unknown git revision(%s)", revision)
+	}
+	// `./` means the path relative to the working directory
+	if _, err = git(dir, "cat-file", "-e", revision+":./"+base); err != nil {
+		return nil, fmt.Errorf("swagger file(%s) does not existed at revision(%s)", filename, revision)
+	}
+	return git(dir, "show", revision+":./"+base)
+}
+
+// readFileAtRevision read and decode the swagger document at the git revision
+func readFileAtRevision(revision, filename string) (interface{}, error) {
+	data, err := gitShow(revision, filename)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := swagger.Unmarshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("decode file(%s) at revision(%s) error(%v)", filename, revision, err)
+	}
+	return doc, nil
+}
+
+// generateAtRevision generate the swagger document from the sources at the git revision,
+// the revision is checked out by `git worktree` into a temporary GOPATH
+// where the repository has the same import path
+func generateAtRevision(revision, projectPath, swaggerGo string, opt *parser.Option) (interface{}, error) {
+	project, err := realPath(projectPath)
+	if err != nil {
+		return nil, err
+	}
+	swaggerFile, err := realPath(swaggerGo)
+	if err != nil {
+		return nil, err
+	}
+	out, err := git(project, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, err
+	}
+	top := strings.TrimSpace(string(out))
+	importPath, err := goPathImport(top)
+	if err != nil {
+		return nil, err
+	}
+	relProject, err := filepath.Rel(top, project)
+	if err != nil {
+		return nil, err
+	}
+	relSwagger, err := filepath.Rel(top, swaggerFile)
+	if err != nil {
+		return nil, err
+	}
+
+	goPath, err := ioutil.TempDir("", "swaggo")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(goPath)
+	worktree := filepath.Join(goPath, "src", filepath.FromSlash(importPath))
+	if err = os.MkdirAll(filepath.Dir(worktree), 0755); err != nil {
+		return nil, err
+	}
+	if _, err = git(top, "worktree", "add", "--detach", worktree, revision); err != nil {
+		return nil, err
+	}
+	defer git(top, "worktree", "remove", "--force", worktree)
+
+	o := *opt
+	o.GoPath = goPath
+	doc, err := parser.Document(filepath.Join(worktree, relProject), filepath.Join(worktree, relSwagger), &o)
+	if err != nil {
+		return nil, fmt.Errorf("generate swagger doc at revision(%s) error(%v)", revision, err)
+	}
+	return doc, nil
+}
+
+// goPathImport the import path of directory in GOPATH
+func goPathImport(dir string) (string, error) {
+	for _, goPath := range filepath.SplitList(os.Getenv("GOPATH")) {
+		src, err := realPath(filepath.Join(goPath, "src"))
+		if err != nil {
+			continue
+		}
+		if rel, err := filepath.Rel(src, dir); err == nil && rel != "." && !strings.HasPrefix(rel, "..") {
+			return filepath.ToSlash(rel), nil
+		}
+	}
+	return "", fmt.Errorf("the git repository(%s) isn't in GOPATH, its import path is unknown", dir)
+}
+
+// realPath the absolute path without symbolic links
+func realPath(filename string) (string, error) {
+	abs, err := filepath.Abs(filename)
+	if err != nil {
+		return "", err
+	}
+	return filepath.EvalSymlinks(abs)
+}
@@ -43,18 +43,91 @@ func main() {
 			Value: "json",
 			Usage: "the type of swagger file (json or yaml)",
 		},
+		cli.StringFlag{
+			Name:  "naming, n",
+			Value: "simple",
+			Usage: "the naming strategy of definitions (simple, package, underscore or path)",
+		},
+		cli.BoolFlag{
+			Name:  "orphans",
+			Usage: "report the unused definitions which are removed from the swagger file",
+		},
+		cli.StringFlag{
+			Name:  "audience, a",
+			Usage: "generate one swagger file per audience, like `public,partner` or `all` for all the audiences in annotations",
+		},
+		cli.StringFlag{
+			Name:  "split",
+			Usage: "split the swagger file into one file per tag or path prefix (tag or prefix) and an index file listing them",
+		},
+		cli.StringFlag{
+			Name:  "base",
+			Usage: "the base swagger file (json or yaml) which is deep merged under the generated doc",
+		},
+		cli.StringFlag{
+			Name:  "overlay",
+			Usage: "the overlay file (json or yaml) whose actions are applied to the generated doc",
+		},
+		cli.BoolFlag{
+			Name:  "external-definitions",
+			Usage: "write the definitions into separate files in `definitions` directory which are referenced by relative `$ref`",
+		},
+		cli.BoolFlag{
+			Name:  "keep-refs",
+			Usage: "keep the external `$ref`s of annotations relative to the output instead of bundling them",
+		},
+		cli.StringFlag{
+			Name:  "param-nesting",
+			Value: "dot",
+			Usage: "the nesting style of the params expanded from structs (dot or bracket)",
+		},
+		cli.StringFlag{
+			Name:  "config",
+			Usage: "the config `file` (json or yaml), its types section maps the fully-qualified go types to swagger types and its contentTypes section defines the aliases of content types",
+		},
+		cli.StringSliceFlag{
+			Name:  "type-mapping, m",
+			Usage: "map the fully-qualified go type to swagger type or external schema (`type=swagger-type`), like github.com/foo/bar.ObjectID=string or github.com/foo/bar.Money=$ref:money.yaml#/Money",
+		},
+		cli.BoolFlag{
+			Name:  "pretty",
+			Usage: "indent the json file",
+		},
+		cli.BoolFlag{
+			Name:  "check, c",
+			Usage: "check if the existing swagger file is up to date instead of writing it",
+		},
 	}
 	app.Action = func(c *cli.Context) error {
-		if err := parser.Parse(c.String("project"),
-			c.String("swagger"),
-			c.String("output"),
-			c.String("type"),
-			c.Bool("dev")); err != nil {
-			return err
+		opt := &parser.Option{
+			Dev:                 c.Bool("dev"),
+			Type:                c.String("type"),
+			Pretty:              c.Bool("pretty"),
+			Naming:              c.String("naming"),
+			Orphan:              c.Bool("orphans"),
+			Audience:            c.String("audience"),
+			Split:               c.String("split"),
+			Base:                c.String("base"),
+			Overlay:             c.String("overlay"),
+			ExternalDefinitions: c.Bool("external-definitions"),
+			KeepRefs:            c.Bool("keep-refs"),
+			Config:              c.String("config"),
+			ParamNesting:        c.String("param-nesting"),
+			TypeMappings:        c.StringSlice("type-mapping"),
+		}
+		if c.Bool("check") {
+			return parser.Check(c.String("project"), c.String("swagger"), c.String("output"), opt)
 		}
-		return nil
+		return parser.Generate(c.String("project"), c.String("swagger"), c.String("output"), opt)
+	}
+	app.Commands = []cli.Command{
+		validateCommand,
+		diffCommand,
+		changelogCommand,
+		mergeCommand,
+		bundleCommand,
 	}
 	if err := app.Run(os.Args); err != nil {
-		log.Printf("[Error] %v", err)
+		log.Fatalf("[Error] %v", err)
 	}
 }
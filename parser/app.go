@@ -6,9 +6,11 @@ import (
 	"go/parser"
 	"go/token"
 	"io/ioutil"
+	"log"
 	"os"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strings"
 
 	"github.com/teambition/swaggo/swagger"
@@ -33,41 +35,528 @@ func init() {
 	}
 }
 
+// Option the options of generating swagger file
+type Option struct {
+	Dev      bool   // develop mode
+	Type     string // the type of swagger file (json or yaml)
+	Pretty   bool   // indent the json file
+	Naming   string // the naming strategy of definitions (simple, package, underscore or path)
+	Orphan   bool   // report the unused definitions which are removed
+	Audience string // generate one swagger file per audience, `all` means all the audiences in annotations
+	Split    string // split the swagger file by tag or path prefix
+	Base     string // the base swagger file which is deep merged under the generated doc
+	Overlay  string // the overlay file which is applied to the generated doc
+	// write the definitions into separate files which are referenced by relative `$ref`
+	ExternalDefinitions bool
+	// keep the external `$ref`s of annotations instead of bundling them into the swagger doc
+	KeepRefs bool
+	Config   string // the config file (json or yaml)
+	// the nesting style of the params expanded from structs (dot or bracket)
+	ParamNesting string
+	// the type mappings like `github.com/foo/bar.ObjectID=string` which win the config file
+	TypeMappings []string
+	// the GOPATH which is searched before the GOPATH environment variable,
+	// like the GOPATH of the project checked out at another revision
+	GoPath string
+}
+
+// swaggerFile the swagger file to output
+type swaggerFile struct {
+	name string      // filename
+	v    interface{} // the swagger doc or the index of split files
+}
+
+// isIndex check if the file is the index of split files
+func (f *swaggerFile) isIndex() bool {
+	_, ok := f.v.([]*indexEntry)
+	return ok
+}
+
+// document convert the swagger doc of file to generic values
+func (f *swaggerFile) document() (map[string]interface{}, error) {
+	if doc, ok := f.v.(map[string]interface{}); ok {
+		return doc, nil
+	}
+	doc, err := f.v.(*swagger.Swagger).ToDocument()
+	if err != nil {
+		return nil, err
+	}
+	f.v = doc
+	return doc.(map[string]interface{}), nil
+}
+
+// indexEntry the entry of index file which lists the split swagger files,
+// it can be used as the `urls` of swagger-ui
+type indexEntry struct {
+	Name string `json:"name" yaml:"name"`
+	URL  string `json:"url" yaml:"url"`
+}
+
 // Parse the project by args
-func Parse(projectPath, swaggerGo, output, t string, dev bool) (err error) {
-	absPPath, err := filepath.Abs(projectPath)
+//
+// Deprecated: use Generate with the options instead
+func Parse(projectPath, swaggerGo, output, t string, dev bool) error {
+	return Generate(projectPath, swaggerGo, output, &Option{Dev: dev, Type: t})
+}
+
+// Generate the swagger files of project by options and write them to output
+func Generate(projectPath, swaggerGo, output string, opt *Option) (err error) {
+	files, err := generateFiles(projectPath, swaggerGo, output, opt)
 	if err != nil {
-		return err
+		return
 	}
-	vendor = filepath.Join(absPPath, "vendor")
-	devMode = dev
+	for _, f := range files {
+		var data []byte
+		if data, err = marshal(f.v, opt); err != nil {
+			return
+		}
+		filename := filepath.Join(output, f.name)
+		if err = os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+			return
+		}
+		if err = ioutil.WriteFile(filename, data, 0644); err != nil {
+			return
+		}
+	}
+	return
+}
 
-	sw := swagger.NewV2()
-	if err = doc2Swagger(projectPath, swaggerGo, dev, sw); err != nil {
+// Document generate the swagger doc of the project in memory,
+// the options which output more than one file are not supported
+func Document(projectPath, swaggerGo string, opt *Option) (interface{}, error) {
+	if opt.Audience != "" || opt.Split != "" || opt.ExternalDefinitions {
+		return nil, fmt.Errorf("the options of audience, split and external definitions output more than one file")
+	}
+	files, err := generateFiles(projectPath, swaggerGo, projectPath, opt)
+	if err != nil {
+		return nil, err
+	}
+	return files[0].document()
+}
+
+// Check generate the swagger docs in memory and compare them with the existing output files,
+// returns an error and prints the differences if they are not the same
+func Check(projectPath, swaggerGo, output string, opt *Option) (err error) {
+	files, err := generateFiles(projectPath, swaggerGo, output, opt)
+	if err != nil {
 		return
 	}
-	var (
-		data     []byte
-		filename string
-	)
+	outdated := []string{}
+	for _, f := range files {
+		filename := filepath.Join(output, f.name)
+		if !fileExists(filename) {
+			return fmt.Errorf("swagger file(%s) does not existed, please generate it first", filename)
+		}
+		var (
+			existed, generated interface{}
+			data               []byte
+		)
+		if existed, err = swagger.ReadFile(filename); err != nil {
+			return
+		}
+		if data, err = marshal(f.v, opt); err != nil {
+			return
+		}
+		if generated, err = swagger.Unmarshal(data); err != nil {
+			return
+		}
+		if diffs := swagger.Compare(existed, generated); len(diffs) != 0 {
+			fmt.Printf("%s: %d difference(s)\n", filename, len(diffs))
+			fmt.Print(swagger.FormatDifferences(diffs))
+			outdated = append(outdated, filename)
+		}
+	}
+	stale, err := staleFiles(output, files, opt)
+	if err != nil {
+		return
+	}
+	for _, filename := range stale {
+		fmt.Printf("%s: not generated any more, please remove it\n", filename)
+	}
+	outdated = append(outdated, stale...)
+	if len(outdated) != 0 {
+		return fmt.Errorf("swagger file(%s) is out of date", strings.Join(outdated, ", "))
+	}
+	return
+}
+
+// staleFiles find the files in output which match the naming of swagger files but aren't generated,
+// like the split file of a removed tag or the definition file of a removed model
+func staleFiles(output string, files []*swaggerFile, opt *Option) ([]string, error) {
+	filename, err := outputFilename(opt.Type)
+	if err != nil {
+		return nil, err
+	}
+	ext := filepath.Ext(filename)
+	prefix := strings.TrimSuffix(filename, ext) + "."
+	generated := map[string]bool{}
+	for _, f := range files {
+		generated[filepath.Clean(f.name)] = true
+	}
+	stale := []string{}
+	err = filepath.Walk(output, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(output, path)
+		if err != nil {
+			return err
+		}
+		dir, base := filepath.Split(rel)
+		if info.IsDir() {
+			// only the output and the directories of definitions like `definitions` or `definitions.<part>`
+			if rel != "." && (dir != "" || (base != definitionsDir && !strings.HasPrefix(base, definitionsDir+"."))) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(base) != ext || generated[rel] {
+			return nil
+		}
+		if dir != "" || base == filename || strings.HasPrefix(base, prefix) {
+			stale = append(stale, path)
+		}
+		return nil
+	})
+	return stale, err
+}
 
-	switch t {
-	case "json":
-		filename = jsonFile
-		data, err = json.Marshal(sw)
-	case "yaml":
-		filename = yamlFile
-		data, err = yaml.Marshal(sw)
+// generateFiles generate the swagger docs which will be written to files
+func generateFiles(projectPath, swaggerGo, output string, opt *Option) ([]*swaggerFile, error) {
+	var keys swagger.SplitKeys
+	switch opt.Split {
+	case "":
+	case splitByTag:
+		keys = swagger.ByTag
+	case splitByPrefix:
+		keys = swagger.ByPathPrefix
 	default:
-		err = fmt.Errorf("missing swagger file type(%s), only support in (json, yaml)", t)
+		return nil, fmt.Errorf("unknown split option(%s), only support in (tag, prefix)", opt.Split)
 	}
+	files, err := generateAudienceFiles(projectPath, swaggerGo, opt)
 	if err != nil {
+		return nil, err
+	}
+	if keys != nil {
+		splitFiles := []*swaggerFile{}
+		for _, f := range files {
+			parts, err := splitFile(f, keys)
+			if err != nil {
+				return nil, err
+			}
+			splitFiles = append(splitFiles, parts...)
+		}
+		files = splitFiles
+	}
+	if err = resolveRefs(files, output, opt); err != nil {
+		return nil, err
+	}
+	if err = patchFiles(files, opt); err != nil {
+		return nil, err
+	}
+	if err = validateFiles(files); err != nil {
+		return nil, err
+	}
+	if opt.ExternalDefinitions {
+		return externalizeFiles(files)
+	}
+	return files, nil
+}
+
+// validateFiles validate the swagger docs which will be written but never block the output,
+// the docs are validated before their definitions are written into separate files
+func validateFiles(files []*swaggerFile) (err error) {
+	for _, f := range files {
+		if f.isIndex() {
+			continue
+		}
+		doc := f.v
+		if sw, ok := f.v.(*swagger.Swagger); ok {
+			if doc, err = sw.ToDocument(); err != nil {
+				return
+			}
+		}
+		for _, e := range swagger.Validate(doc) {
+			log.Printf("[Warning] %s %v\n", f.name, e)
+		}
+	}
+	return
+}
+
+// resolveRefs bundle the external references of annotations into the swagger docs,
+// or keep them relative to the output files
+func resolveRefs(files []*swaggerFile, output string, opt *Option) error {
+	absOutput, err := filepath.Abs(output)
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		if sw, ok := f.v.(*swagger.Swagger); !ok || !sw.HasExternalRefs() {
+			continue
+		}
+		doc, err := f.document()
+		if err != nil {
+			return err
+		}
+		filename := filepath.Join(absOutput, f.name)
+		if !opt.KeepRefs {
+			if f.v, err = swagger.BundleDocument(doc, filename); err != nil {
+				return err
+			}
+			continue
+		}
+		f.v = swagger.RewriteRefs(doc, func(ref string) string {
+			if !swagger.IsExternalRef(ref) {
+				return ref
+			}
+			file, pointer := ref, ""
+			if idx := strings.Index(ref, "#"); idx != -1 {
+				file, pointer = ref[:idx], ref[idx:]
+			}
+			rel, err := filepath.Rel(filepath.Dir(filename), filepath.FromSlash(file))
+			if err != nil {
+				return ref
+			}
+			return filepath.ToSlash(rel) + pointer
+		})
+	}
+	return nil
+}
+
+// externalizeFiles write the definitions of swagger files into separate files,
+// like swagger.json => definitions/<name>.json, swagger.<part>.json => definitions.<part>/<name>.json
+func externalizeFiles(files []*swaggerFile) ([]*swaggerFile, error) {
+	r := []*swaggerFile{}
+	for _, f := range files {
+		r = append(r, f)
+		if f.isIndex() {
+			continue
+		}
+		doc, err := f.document()
+		if err != nil {
+			return nil, err
+		}
+		ext := filepath.Ext(f.name)
+		dir := definitionsDir + strings.TrimPrefix(strings.TrimSuffix(f.name, ext), swaggerName)
+		definitions, err := swagger.ExternalizeDefinitions(doc, dir, ext)
+		if err != nil {
+			return nil, err
+		}
+		filenames := make([]string, 0, len(definitions))
+		for filename := range definitions {
+			filenames = append(filenames, filename)
+		}
+		sort.Strings(filenames)
+		for _, filename := range filenames {
+			r = append(r, &swaggerFile{filename, definitions[filename]})
+		}
+	}
+	return r, nil
+}
+
+// patchFiles deep merge the base doc under the generated swagger docs
+// and apply the overlay to them, the index files are skipped
+func patchFiles(files []*swaggerFile, opt *Option) (err error) {
+	if opt.Base == "" && opt.Overlay == "" {
 		return
 	}
-	return ioutil.WriteFile(filepath.Join(output, filename), data, 0644)
+	var base interface{}
+	if opt.Base != "" {
+		if base, err = swagger.ReadFile(opt.Base); err != nil {
+			return
+		}
+	}
+	var overlay *swagger.Overlay
+	if opt.Overlay != "" {
+		doc, err := swagger.ReadFile(opt.Overlay)
+		if err != nil {
+			return err
+		}
+		if overlay, err = swagger.ParseOverlay(doc); err != nil {
+			return fmt.Errorf("parse overlay(%s) error(%v)", opt.Overlay, err)
+		}
+	}
+	for _, f := range files {
+		if f.isIndex() {
+			continue
+		}
+		var doc interface{}
+		if doc, err = f.document(); err != nil {
+			return
+		}
+		if base != nil {
+			doc = swagger.Extend(doc, base)
+		}
+		if overlay != nil {
+			var unmatched []string
+			doc, unmatched = overlay.Apply(doc)
+			for _, target := range unmatched {
+				log.Printf("[Warning] the target(%s) of overlay matches nothing in %s\n", target, f.name)
+			}
+		}
+		f.v = doc
+	}
+	return
+}
+
+// splitFile split the swagger file into parts and the index file listing them,
+// like swagger.json => swagger.index.json, swagger.<part>.json...
+func splitFile(f *swaggerFile, keys swagger.SplitKeys) ([]*swaggerFile, error) {
+	parts := f.v.(*swagger.Swagger).Split(keys)
+	names := make([]string, 0, len(parts))
+	for name := range parts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	indexFile := appendFilename(f.name, indexName)
+	files := []*swaggerFile{nil}
+	index := []*indexEntry{}
+	seen := map[string]string{indexFile: indexName}
+	for _, name := range names {
+		filename := appendFilename(f.name, swagger.FileSafeName(name))
+		if other, ok := seen[filename]; ok {
+			return nil, fmt.Errorf("the split files of (%s) and (%s) are the same file(%s)", other, name, filename)
+		}
+		seen[filename] = name
+		files = append(files, &swaggerFile{filename, parts[name]})
+		index = append(index, &indexEntry{name, filename})
+	}
+	files[0] = &swaggerFile{indexFile, index}
+	return files, nil
+}
+
+// appendFilename append the name before the extension of filename
+func appendFilename(filename, name string) string {
+	ext := filepath.Ext(filename)
+	return strings.TrimSuffix(filename, ext) + "." + name + ext
+}
+
+// generateAudienceFiles generate the swagger docs of audiences
+func generateAudienceFiles(projectPath, swaggerGo string, opt *Option) ([]*swaggerFile, error) {
+	filename, err := outputFilename(opt.Type)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { audience = "" }()
+	audience = ""
+	names := []string{}
+	for _, a := range strings.Split(opt.Audience, ",") {
+		if a = strings.TrimSpace(a); a != "" {
+			names = append(names, a)
+		}
+	}
+	if len(names) == 0 || (len(names) == 1 && names[0] == allAudiences) {
+		sw, err := generate(projectPath, swaggerGo, opt)
+		if err != nil {
+			return nil, err
+		}
+		if len(names) == 0 {
+			return []*swaggerFile{{filename, sw}}, nil
+		}
+		// all the audiences found in annotations
+		names = sortedAudiences()
+		if len(names) == 0 {
+			return nil, fmt.Errorf("there is no audience in annotations(%s)", ctrlAudience)
+		}
+	}
+
+	files := []*swaggerFile{}
+	for _, name := range names {
+		audience = name
+		sw, err := generate(projectPath, swaggerGo, opt)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, &swaggerFile{appendFilename(filename, name), sw})
+	}
+	return files, nil
+}
+
+// generate the swagger doc of the project in memory
+func generate(projectPath, swaggerGo string, opt *Option) (*swagger.Swagger, error) {
+	absPPath, err := filepath.Abs(projectPath)
+	if err != nil {
+		return nil, err
+	}
+	vendor = filepath.Join(absPPath, "vendor")
+	devMode = opt.Dev
+	if opt.GoPath != "" {
+		defer func(paths []string) { goPaths = paths }(goPaths)
+		goPaths = append([]string{opt.GoPath}, goPaths...)
+	}
+	switch opt.Naming {
+	case "":
+		namingStrategy = simpleNaming
+	case simpleNaming, packageNaming, underscoreNaming, pathNaming:
+		namingStrategy = opt.Naming
+	default:
+		return nil, fmt.Errorf("unknown naming strategy(%s), only support in (simple, package, underscore, path)", opt.Naming)
+	}
+	switch opt.ParamNesting {
+	case "":
+		paramNesting = dotNesting
+	case dotNesting, bracketNesting:
+		paramNesting = opt.ParamNesting
+	default:
+		return nil, fmt.Errorf("unknown param nesting(%s), only support in (dot, bracket)", opt.ParamNesting)
+	}
+	c, err := loadConfig(opt)
+	if err != nil {
+		return nil, err
+	}
+	if typeMappings, err = loadTypeMappings(opt, c); err != nil {
+		return nil, err
+	}
+	if contentTypeAliases, err = loadContentTypes(c); err != nil {
+		return nil, err
+	}
+
+	sw := swagger.NewV2()
+	if err = doc2Swagger(projectPath, swaggerGo, opt.Dev, sw); err != nil {
+		return nil, err
+	}
+	// the models referenced by private apis are parsed too
+	for _, name := range sw.PruneDefinitions() {
+		if opt.Orphan {
+			log.Printf("[Info] unused definition(%s) is removed\n", name)
+		}
+	}
+	return sw, nil
+}
+
+// outputFilename the name of swagger file by type
+func outputFilename(t string) (string, error) {
+	switch t {
+	case jsonType:
+		return jsonFile, nil
+	case yamlType:
+		return yamlFile, nil
+	}
+	return "", fmt.Errorf("missing swagger file type(%s), only support in (json, yaml)", t)
+}
+
+// marshal encode the swagger doc by type,
+// both json and yaml encoders sort the keys of maps
+func marshal(v interface{}, opt *Option) (data []byte, err error) {
+	switch opt.Type {
+	case jsonType:
+		if !opt.Pretty {
+			return json.Marshal(v)
+		}
+		if data, err = json.MarshalIndent(v, "", "  "); err != nil {
+			return
+		}
+		return append(data, '\n'), nil
+	case yamlType:
+		return yaml.Marshal(v)
+	}
+	return nil, fmt.Errorf("missing swagger file type(%s), only support in (json, yaml)", opt.Type)
 }
 
 func doc2Swagger(projectPath, swaggerGo string, dev bool, sw *swagger.Swagger) error {
+	cachedModels = map[string]*kv{}
+	audiences = map[string]bool{}
 	f, err := parser.ParseFile(token.NewFileSet(), swaggerGo, nil, parser.ParseComments)
 	if err != nil {
 		return err
@@ -82,8 +571,11 @@ func doc2Swagger(projectPath, swaggerGo string, dev bool, sw *swagger.Swagger) e
 				case tagTrimPrefixAndSpace(&s, appTitle):
 					sw.Infos.Title = s
 				case tagTrimPrefixAndSpace(&s, appDesc):
+					if s, err = descriptionFile(swaggerGo, s); err != nil {
+						return err
+					}
 					if sw.Infos.Description != "" {
-						sw.Infos.Description += "<br>" + s
+						sw.Infos.Description += "\n" + s
 					} else {
 						sw.Infos.Description = s
 					}
@@ -106,14 +598,22 @@ func doc2Swagger(projectPath, swaggerGo string, dev bool, sw *swagger.Swagger) e
 				case tagTrimPrefixAndSpace(&s, appBasePath):
 					sw.BasePath = s
 				case tagTrimPrefixAndSpace(&s, appConsumes):
-					sw.Consumes = contentTypeByDoc(s)
+					if sw.Consumes, err = contentTypeByDoc(s); err != nil {
+						return fmt.Errorf("(%s) %s error(%v)", swaggerGo, appConsumes, err)
+					}
 				case tagTrimPrefixAndSpace(&s, appProduces):
-					sw.Produces = contentTypeByDoc(s)
+					if sw.Produces, err = contentTypeByDoc(s); err != nil {
+						return fmt.Errorf("(%s) %s error(%v)", swaggerGo, appProduces, err)
+					}
 				}
 			}
 		}
 	}
 
+	if sw.Infos.Description == "" {
+		sw.Infos.Description = docDescription(f.Doc)
+	}
+
 	// Analyse controller package
 	// like:
 	// swagger.go
@@ -1,6 +1,13 @@
 package parser
 
 import (
+	"bytes"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -31,6 +38,335 @@ func TestAppSuite(t *testing.T) {
 	suite.Run(t, as)
 }
 
+func TestNamingStrategy(t *testing.T) {
+	assert := assert.New(t)
+	defer func() { namingStrategy = simpleNaming }()
+
+	namingStrategy = packageNaming
+	sw := swagger.NewV2()
+	assert.Nil(doc2Swagger("../test", "../test/swagger.go", true, sw))
+	assert.NotNil(sw.Definitions["api.APIError"])
+	assert.NotNil(sw.Definitions["api.SimpleStructure"])
+	assert.NotNil(sw.Definitions["SubSimpleStructure"])
+	assert.Equal("#/definitions/api.APIError", sw.Paths["/testapi/get-struct3"].Post.Responses["400"].Schema.Ref)
+
+	namingStrategy = pathNaming
+	sw = swagger.NewV2()
+	assert.Nil(doc2Swagger("../test", "../test/swagger.go", true, sw))
+	assert.NotNil(sw.Definitions["github.com/teambition/swaggo/test/pkg/api.APIError"])
+}
+
+func TestAudience(t *testing.T) {
+	assert := assert.New(t)
+	defer func() { audience = "" }()
+
+	audience = "public"
+	sw := swagger.NewV2()
+	assert.Nil(doc2Swagger("../test", "../test/swagger.go", true, sw))
+	assert.Nil(sw.Paths["/testapi/get-struct3"].Delete)
+	assert.NotNil(sw.Paths["/testapi/get-struct3"].Post)
+	assert.Nil(sw.Definitions["StructureWithSlice"].Properties["Name"])
+	assert.Equal([]string{"internal", "partner"}, sortedAudiences())
+
+	audience = "partner"
+	sw = swagger.NewV2()
+	assert.Nil(doc2Swagger("../test", "../test/swagger.go", true, sw))
+	assert.Nil(sw.Paths["/testapi/get-struct3"].Delete)
+	assert.NotNil(sw.Definitions["StructureWithSlice"].Properties["Name"])
+}
+
+func TestExternalRefs(t *testing.T) {
+	assert := assert.New(t)
+
+	files, err := generateFiles("../test", "../test/swagger.go", "../test", &Option{Type: jsonType, Dev: true})
+	assert.Nil(err)
+	doc, err := files[0].document()
+	assert.Nil(err)
+	assert.NotNil(doc["definitions"].(map[string]interface{})["Error"])
+
+	files, err = generateFiles("../test", "../test/swagger.go", "../test", &Option{Type: jsonType, Dev: true, KeepRefs: true})
+	assert.Nil(err)
+	doc, err = files[0].document()
+	assert.Nil(err)
+	assert.Nil(doc["definitions"].(map[string]interface{})["Error"])
+	op := doc["paths"].(map[string]interface{})["/testapi/get-struct3"].(map[string]interface{})["post"].(map[string]interface{})
+	assert.Equal("pkg/api/common.yaml#/definitions/Error", op["responses"].(map[string]interface{})["500"].(map[string]interface{})["schema"].(map[string]interface{})["$ref"])
+}
+
+func TestValidateFiles(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "swaggo")
+	assert.Nil(err)
+	defer os.RemoveAll(dir)
+	swaggerGo := filepath.Join(dir, "swagger.go")
+	assert.Nil(ioutil.WriteFile(swaggerGo, []byte("// @Version 1.0.0\npackage main\n\nimport _ \"github.com/teambition/swaggo/test/pkg/api\"\n"), 0644))
+	base := filepath.Join(dir, "base.yaml")
+	assert.Nil(ioutil.WriteFile(base, []byte("info: {title: Base API, license: {name: MIT}}\n"), 0644))
+
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+	_, err = generateFiles("../test", swaggerGo, dir, &Option{Type: jsonType, Dev: true})
+	assert.Nil(err)
+	assert.Contains(buf.String(), "swagger.json #/info: missing required property \"title\"")
+	assert.Contains(buf.String(), "swagger.json #/info/license")
+	buf.Reset()
+	// the title is supplied by the base file
+	files, err := generateFiles("../test", swaggerGo, dir, &Option{Type: jsonType, Dev: true, Base: base})
+	assert.Nil(err)
+	assert.NotContains(buf.String(), "#/info")
+	doc, err := files[0].document()
+	assert.Nil(err)
+	assert.Equal("Base API", doc["info"].(map[string]interface{})["title"])
+}
+
+func TestLegacyParse(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "swaggo")
+	assert.Nil(err)
+	defer os.RemoveAll(dir)
+	assert.Nil(Parse("../test", "../test/swagger.go", dir, jsonType, true))
+	assert.Nil(Check("../test", "../test/swagger.go", dir, &Option{Type: jsonType, Dev: true}))
+}
+
+func TestCheckStaleFiles(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "swaggo")
+	assert.Nil(err)
+	defer os.RemoveAll(dir)
+	opt := &Option{Type: jsonType, Dev: true, Split: splitByTag, ExternalDefinitions: true}
+	assert.Nil(Generate("../test", "../test/swagger.go", dir, opt))
+	assert.Nil(Check("../test", "../test/swagger.go", dir, opt))
+
+	// the files of others are ignored
+	assert.Nil(ioutil.WriteFile(filepath.Join(dir, "package.json"), []byte("{}"), 0644))
+	assert.Nil(Check("../test", "../test/swagger.go", dir, opt))
+	// the split file of a removed tag
+	assert.Nil(ioutil.WriteFile(filepath.Join(dir, "swagger.removed.json"), []byte("{}"), 0644))
+	err = Check("../test", "../test/swagger.go", dir, opt)
+	assert.NotNil(err)
+	assert.Contains(err.Error(), "swagger.removed.json")
+	assert.Nil(os.Remove(filepath.Join(dir, "swagger.removed.json")))
+	// the definition file of a removed model
+	defs, err := filepath.Glob(filepath.Join(dir, definitionsDir+"*"))
+	assert.Nil(err)
+	assert.NotEmpty(defs)
+	assert.Nil(ioutil.WriteFile(filepath.Join(defs[0], "Removed.json"), []byte("{}"), 0644))
+	err = Check("../test", "../test/swagger.go", dir, opt)
+	assert.NotNil(err)
+	assert.Contains(err.Error(), "Removed.json")
+}
+
+func TestTypeMappings(t *testing.T) {
+	assert := assert.New(t)
+	defer func() {
+		typeMappings = map[string]string{}
+		contentTypeAliases = map[string]string{}
+	}()
+
+	opt := &Option{
+		Type:         jsonType,
+		Dev:          true,
+		Config:       "../test/swaggo.yaml",
+		TypeMappings: []string{"github.com/teambition/swaggo/test/pkg/api.Level=$ref:../test/pkg/api/common.yaml#/definitions/Error"},
+	}
+	sw, err := generate("../test", "../test/swagger.go", opt)
+	assert.Nil(err)
+	props := sw.Definitions["JSONStructure"].Properties
+	assert.Equal("string", props["Owner"].Type)
+	assert.Equal("objectid", props["Owner"].Format)
+	assert.Equal("integer", props["Created"].Type)
+	assert.Equal("int32", props["Created"].Format)
+	assert.True(strings.HasSuffix(props["Level"].Ref, "/test/pkg/api/common.yaml#/definitions/Error"), props["Level"].Ref)
+
+	opt.TypeMappings = []string{"ObjectID=string"}
+	_, err = generate("../test", "../test/swagger.go", opt)
+	assert.NotNil(err)
+	opt.TypeMappings = []string{"github.com/teambition/swaggo/test/pkg/api.Level=text"}
+	_, err = generate("../test", "../test/swagger.go", opt)
+	assert.NotNil(err)
+}
+
+func TestContentTypes(t *testing.T) {
+	assert := assert.New(t)
+	defer func() { contentTypeAliases = map[string]string{} }()
+
+	types, err := contentTypeByDoc("json, text/csv,application/problem+json; charset=utf-8")
+	assert.Nil(err)
+	assert.Equal([]string{"application/json", "text/csv", "application/problem+json; charset=utf-8"}, types)
+	_, err = contentTypeByDoc("jsonapi")
+	assert.NotNil(err)
+	_, err = contentTypeByDoc("text/")
+	assert.NotNil(err)
+
+	c, err := readConfig("../test/swaggo.yaml")
+	assert.Nil(err)
+	contentTypeAliases, err = loadContentTypes(c)
+	assert.Nil(err)
+	types, err = contentTypeByDoc("jsonapi,problem")
+	assert.Nil(err)
+	assert.Equal([]string{"application/vnd.api+json", "application/problem+json"}, types)
+	_, err = loadContentTypes(&Config{ContentTypes: map[string]string{"json": "application/vnd.api+json"}})
+	assert.NotNil(err)
+	_, err = loadContentTypes(&Config{ContentTypes: map[string]string{"csv": "csv"}})
+	assert.NotNil(err)
+}
+
+func TestResponseProduces(t *testing.T) {
+	assert := assert.New(t)
+
+	responses := map[string]*swagger.Response{}
+	assert.Nil(addResponse(responses, "200", &swagger.Response{Description: "OK", Schema: &swagger.Schema{Type: "object"}}, []string{"application/json"}))
+	assert.Nil(addResponse(responses, "200", &swagger.Response{Schema: &swagger.Schema{Type: "string"}}, []string{"text/csv"}))
+	assert.NotNil(addResponse(responses, "200", &swagger.Response{}, []string{"text/csv"}))
+	assert.Equal("OK", responses["200"].Description)
+	assert.Equal("object", responses["200"].Schema.Type)
+	assert.Len(responses["200"].Content, 2)
+
+	// the response without media types after the representations
+	assert.Nil(addResponse(responses, "200", &swagger.Response{Description: "Users", Schema: &swagger.Schema{Type: "array"}}, nil))
+	assert.Equal("Users", responses["200"].Description)
+	assert.Equal("array", responses["200"].Schema.Type)
+	assert.Len(responses["200"].Content, 2)
+	// the response without media types before the representations
+	assert.Nil(addResponse(responses, "400", &swagger.Response{Description: "Error", Schema: &swagger.Schema{Type: "object"}}, nil))
+	assert.Nil(addResponse(responses, "400", &swagger.Response{Description: "Problem", Schema: &swagger.Schema{Type: "string"}}, []string{"application/problem+json"}))
+	assert.Equal("Error", responses["400"].Description)
+	assert.Equal("object", responses["400"].Schema.Type)
+	assert.Equal("string", responses["400"].Content["application/problem+json"].Schema.Type)
+
+	assert.True(isMediaTypes("text/csv,application/json"))
+	assert.False(isMediaTypes("json"))
+	assert.False(isMediaTypes("the csv/tsv file"))
+
+	s := &swagger.Swagger{Produces: []string{"application/json"}}
+	opt := &swagger.Operation{Responses: map[string]*swagger.Response{"200": responses["200"]}}
+	responseProduces(s, opt)
+	assert.Equal([]string{"application/json", "text/csv"}, opt.Produces)
+	assert.Equal([]string{"application/json"}, s.Produces)
+	opt = &swagger.Operation{Responses: map[string]*swagger.Response{"200": {Description: "OK"}}}
+	responseProduces(s, opt)
+	assert.Nil(opt.Produces)
+	// the response without media types is json when there is no produces
+	s = &swagger.Swagger{}
+	opt = &swagger.Operation{Responses: map[string]*swagger.Response{"200": {Description: "OK"}, "400": responses["400"]}}
+	responseProduces(s, opt)
+	assert.Equal([]string{"application/json", "application/problem+json"}, opt.Produces)
+	opt = &swagger.Operation{Responses: map[string]*swagger.Response{"200": {Description: "OK"}}}
+	responseProduces(s, opt)
+	assert.Nil(opt.Produces)
+}
+
+func TestCloneResult(t *testing.T) {
+	assert := assert.New(t)
+
+	name := &result{kind: innerKind, buildin: "string", sType: "string"}
+	r := &result{
+		kind:     arrayKind,
+		item:     &result{kind: objectKind, items: map[string]*result{"name": name}, required: []string{"name"}},
+		required: []string{},
+	}
+	r.item.fields = map[string]*jsonField{"name": {r: name}}
+	q := r.clone()
+	q.item.items["name"].desc = "the name"
+	q.item.required[0] = "id"
+	assert.Equal("", name.desc)
+	assert.Equal([]string{"name"}, r.item.required)
+	assert.True(q.item.fields["name"].r == q.item.items["name"])
+
+	max := float64(10)
+	c, err := (&result{kind: arrayKind, item: &result{kind: arrayKind, item: name}}).constrain(&fieldRules{items: &fieldRules{items: &fieldRules{max: &max}}})
+	assert.Nil(err)
+	assert.Equal(int64(10), *c.item.item.validation.MaxLength)
+	assert.Nil(name.validation.MaxLength)
+}
+
+func TestParseTag(t *testing.T) {
+	assert := assert.New(t)
+
+	tag, err := parseTag("`json:\"id\" swaggo:\"true,the id,1\"`", "int")
+	assert.Nil(err)
+	assert.True(tag.required)
+	assert.Equal("the id", tag.desc)
+	assert.Equal(1, tag.def)
+	tag, err = parseTag("`swaggo:\"required=false;min=1;desc=a, b\"`", "int")
+	assert.Nil(err)
+	assert.False(tag.required)
+	assert.Equal("a, b", tag.desc)
+	assert.Equal(float64(1), *tag.rules.min)
+	_, err = parseTag("`swaggo:\"required;unknown=1\"`", "int")
+	assert.NotNil(err)
+	_, err = parseTag("`swaggo:\"readonly=maybe\"`", "int")
+	assert.NotNil(err)
+	tag, err = parseTag("`validate:\"required\" swaggo:\"desc=the name\"`", "string")
+	assert.Nil(err)
+	assert.True(tag.required)
+	assert.Equal("the name", tag.desc)
+	// the legacy positional tags
+	for _, s := range []string{"True,the id", "1,the id", "-,the id"} {
+		tag, err = parseTag("`swaggo:\""+s+"\"`", "int")
+		assert.Nil(err, s)
+		assert.False(tag.required, s)
+		assert.Equal("the id", tag.desc, s)
+	}
+	// the positional tags with `;` or `=` in description
+	for s, desc := range map[string]string{"true,see A; B": "see A; B", "true,a=b; c": "a=b; c", ",x;y": "x;y"} {
+		tag, err = parseTag("`swaggo:\""+s+"\"`", "int")
+		assert.Nil(err, s)
+		assert.Equal(desc, tag.desc, s)
+	}
+	assert.False(isKeyedTag("true,see A; B"))
+	assert.True(isKeyedTag("readonly"))
+	assert.True(isKeyedTag("unknown=1;desc=x"))
+}
+
+func TestParamNesting(t *testing.T) {
+	assert := assert.New(t)
+	defer func() { paramNesting = dotNesting }()
+
+	sw, err := generate("../test", "../test/swagger.go", &Option{Dev: true, ParamNesting: bracketNesting})
+	assert.Nil(err)
+	names := []string{}
+	for _, p := range sw.Paths["/testapi/get-struct3"].Post.Parameters {
+		names = append(names, p.Name)
+	}
+	assert.Contains(names, "owner[id]")
+	assert.Nil(sw.Definitions["ListFilter"])
+	_, err = generate("../test", "../test/swagger.go", &Option{Dev: true, ParamNesting: "slash"})
+	assert.NotNil(err)
+}
+
+func TestParamAttrs(t *testing.T) {
+	assert := assert.New(t)
+
+	p, attrs := splitParamAttrs([]string{"order", "query", "string", "false", "Order", "enum(asc,desc)", "asc", "deprecated"})
+	assert.Equal([]string{"order", "query", "string", "false", "Order", "asc"}, p)
+	assert.Equal([]string{"enum(asc,desc)", "deprecated"}, attrs)
+	_, err := parseParamAttrs([]string{"collectionFormat(comma)"})
+	assert.NotNil(err)
+	_, err = parseParamAttrs([]string{"minimum(one)"})
+	assert.NotNil(err)
+	pa, err := parseParamAttrs([]string{"collectionFormat(multi)", "example(x)"})
+	assert.Nil(err)
+	assert.NotNil(pa.apply(&swagger.Parameter{In: "query", Type: "string"}, "string"))
+	pa, err = parseParamAttrs([]string{"example(x)"})
+	assert.Nil(err)
+	assert.NotNil(pa.apply(&swagger.Parameter{In: "query", Type: "integer"}, "int"))
+}
+
+func TestDescriptionFile(t *testing.T) {
+	assert := assert.New(t)
+
+	desc, err := descriptionFile("../test/pkg/api/api.go", "get struct")
+	assert.Nil(err)
+	assert.Equal("get struct", desc)
+	_, err = descriptionFile("../test/pkg/api/api.go", "file(docs/missing.md)")
+	assert.NotNil(err)
+}
+
 type AppSuite struct {
 	suite.Suite
 	*swagger.Swagger
@@ -71,8 +407,75 @@ func (suite *AppSuite) TestSwagger() {
 	assert.NotNil(router)
 	assert.NotNil(router.Get)
 	assert.Equal([]string{"testapi"}, router.Get.Tags)
-	assert.Equal("get string by ID summary<br>multi line", router.Get.Summary)
-	assert.Equal("get string by ID desc<br>multi line", router.Get.Description)
+	assert.Equal("get string by ID summary multi line", router.Get.Summary)
+	assert.Equal("get string by ID desc\nmulti line", router.Get.Description)
+	// the params expanded from structs
+	params := map[string]*swagger.Parameter{}
+	names := []string{}
+	for _, p := range suite.Paths["/testapi/get-struct3"].Post.Parameters {
+		params[p.Name] = p
+		names = append(names, p.Name)
+	}
+	assert.Equal([]string{"body", "offset", "limit", "q", "status", "sort", "owner.id", "owner.Name", "team.id", "team.Name", "cursor", "created", "X-Request-Id"}, names)
+	assert.Equal("query", params["q"].In)
+	assert.Equal("the keyword to search", params["q"].Description)
+	assert.Equal(int64(50), *params["q"].MaxLength)
+	assert.True(params["limit"].Required)
+	assert.False(params["offset"].Required)
+	assert.Equal(float64(0), *params["offset"].Minimum)
+	assert.Equal("array", params["status"].Type)
+	assert.Equal([]interface{}{"active", "archived"}, params["status"].Items.Enum)
+	assert.Equal("created", params["sort"].Default)
+	assert.Equal([]interface{}{"created", "updated"}, params["sort"].Enum)
+	// the fields of optional struct are optional
+	assert.False(params["owner.id"].Required)
+	assert.True(params["team.id"].Required)
+	assert.False(params["team.Name"].Required)
+	// the path params are always required
+	pathParam := suite.Paths["/testapi/get-struct-array-by-string/{some_id}"].Put.Parameters[0]
+	assert.Equal("some_id", pathParam.Name)
+	assert.Equal("path", pathParam.In)
+	assert.True(pathParam.Required)
+	// named by form tag though it's ignored in json
+	assert.True(params["cursor"].Required)
+	assert.Equal("date-time", params["created"].Format)
+	assert.Equal("header", params["X-Request-Id"].In)
+	assert.True(params["X-Request-Id"].Required)
+
+	// the attributes of params
+	params = map[string]*swagger.Parameter{}
+	for _, p := range suite.Paths["/testapi/get-simple-array-by-string/{some_id}"].Post.Parameters {
+		params[p.Name] = p
+	}
+	assert.Equal("^[a-z]+$", params["some_id"].Pattern)
+	assert.Equal(float64(1), *params["limit"].Minimum)
+	assert.Equal(float64(100), *params["limit"].Maximum)
+	assert.Equal(10, params["limit"].Example)
+	assert.Equal("Order", params["order"].Description)
+	assert.Equal("asc", params["order"].Default)
+	assert.Equal([]interface{}{"asc", "desc"}, params["order"].Enum)
+	assert.True(params["order"].Deprecated)
+	assert.Equal("multi", params["ids"].CollectionFormat)
+	assert.True(params["ids"].AllowEmptyValue)
+	assert.Equal(int64(10), *params["ids"].MaxItems)
+	assert.Equal([]interface{}{1, 2, 3}, params["ids"].Items.Enum)
+
+	// the description in file
+	op := suite.Paths["/testapi/get-struct-by-int/{some_id}"].Get
+	assert.Equal("# Get struct by ID\n\nThe struct has an **embedded** structure.", op.Description)
+	assert.Equal([]string{"application/json", "text/csv", "application/problem+json"}, op.Produces)
+	// the representations of media types
+	resp := op.Responses["200"]
+	assert.Equal("Success", resp.Description)
+	assert.Equal("#/definitions/StructureWithEmbededStructure", resp.Schema.Ref)
+	assert.Equal(resp.Schema, resp.Content["application/json"].Schema)
+	assert.Equal("string", resp.Content["text/csv"].Schema.Type)
+	assert.Equal("#/definitions/APIError", op.Responses["400"].Content["application/problem+json"].Schema.Ref)
+	assert.Nil(op.Responses["404"].Content)
+	// the free text of doc
+	op = suite.Paths["/testapi/get-struct2-by-int/{some_id}"].Get
+	assert.Equal("GetStruct2ByInt get struct2 by ID.", op.Summary)
+	assert.Equal("GetStruct2ByInt get struct2 by ID. The struct2 has\nan *embedded* pointer.\n\n- the offset starts from 0\n- the limit is 10 at most", op.Description)
 	assert.Equal("testapi.GetStringByInt", router.Get.OperationID)
 	assert.Equal([]string{"application/json", "text/plain", "application/xml", "text/html"}, router.Get.Consumes)
 	assert.Equal([]string{"application/json", "text/plain", "application/xml", "text/html"}, router.Get.Produces)
@@ -123,8 +526,94 @@ func (suite *AppSuite) TestSwagger() {
 	assert.Equal("integer", inhertStruct.Properties["age"].Type)
 	assert.Equal("int32", inhertStruct.Properties["age"].Format)
 
-	assert.Equal("#/definitions/SimpleStructure_1", inhertStruct.Properties["sub"].Ref)
+	// renamed by `@name`
+	assert.Equal("#/definitions/SubSimpleStructure", inhertStruct.Properties["sub"].Ref)
 	assert.Equal("object", inhertStruct.Properties["sub"].Type)
+	assert.NotNil(suite.Definitions["SimpleStructure"].Properties["age"])
+	assert.Equal("the user id", suite.Definitions["SubSimpleStructure"].Properties["id"].Description)
+
+	// encoding/json semantics
+	jsonStruct := suite.Definitions["JSONStructure"]
+	assert.NotNil(jsonStruct)
+	keys := []string{}
+	for k := range jsonStruct.Properties {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	assert.Equal([]string{"Created", "Extra", "IP", "Level", "Name", "Null", "Owner", "Raw", "Shadow", "Tagged", "Timeout", "TypeString", "URL", "X", "Y", "count", "id", "memo"}, keys)
+	// the types without marshalers are objects in json
+	assert.Equal("#/definitions/URL", jsonStruct.Properties["URL"].Ref)
+	assert.Equal("#/definitions/NullString", jsonStruct.Properties["Null"].Ref)
+	assert.Equal("string", jsonStruct.Properties["count"].Type)
+	assert.Equal("int64", jsonStruct.Properties["count"].Format)
+	assert.Equal("integer", jsonStruct.Properties["Tagged"].Type)
+	assert.Equal("string", jsonStruct.Properties["Shadow"].Type)
+	assert.Empty(jsonStruct.Required)
+	// serialize differently from the layout
+	assert.Equal("integer", jsonStruct.Properties["Created"].Type)
+	assert.Equal("int64", jsonStruct.Properties["Created"].Format)
+	assert.Equal("string", jsonStruct.Properties["Level"].Type)
+	assert.Equal("object", jsonStruct.Properties["Raw"].Type)
+	assert.Equal("int64", jsonStruct.Properties["Timeout"].Format)
+	assert.Equal("object", jsonStruct.Properties["Extra"].Type)
+	assert.Equal("string", jsonStruct.Properties["IP"].Type)
+
+	// descriptions from comments
+	apiErr := suite.Definitions["APIError"]
+	assert.Equal("APIError the error of api", apiErr.Description)
+	assert.Equal("the code of error,\nsee the documents of errors", apiErr.Properties["ErrorCode"].Description)
+	assert.Equal("the message of error", apiErr.Properties["ErrorMessage"].Description)
+	assert.Equal("", suite.Definitions["SubSimpleStructure"].Description)
+	assert.Equal("the user age", suite.Definitions["SimpleStructure"].Properties["age"].Description)
+	assert.Equal("", suite.Definitions["StructureWithSlice"].Properties["Name"].Description)
+
+	// validation constraints
+	validated := suite.Definitions["ValidatedStructure"]
+	assert.NotNil(validated)
+	assert.Equal(int64(1), *validated.Properties["name"].MinLength)
+	assert.Equal(int64(100), *validated.Properties["name"].MaxLength)
+	assert.Equal("email", validated.Properties["email"].Format)
+	assert.Equal([]interface{}{"red", "green", "light blue"}, validated.Properties["color"].Enum)
+	assert.Equal(float64(0), *validated.Properties["age"].Minimum)
+	assert.Equal(float64(150), *validated.Properties["age"].Maximum)
+	assert.True(validated.Properties["age"].ExclusiveMaximum)
+	assert.Equal("string", validated.Properties["count"].Type)
+	assert.Nil(validated.Properties["count"].Minimum)
+	assert.Equal([]interface{}{"1", "2", "3"}, validated.Properties["count"].Enum)
+	assert.Equal(int64(6), *validated.Properties["code"].MinLength)
+	assert.Equal(int64(6), *validated.Properties["code"].MaxLength)
+	assert.NotEmpty(validated.Properties["code"].Pattern)
+	assert.Equal(int64(1), *validated.Properties["tags"].MinItems)
+	assert.True(validated.Properties["tags"].UniqueItems)
+	assert.Equal(int64(10), *validated.Properties["tags"].Items.MaxLength)
+	assert.Equal(int64(255), *validated.Properties["remark"].MaxLength)
+	assert.Equal([]interface{}{"admin"}, validated.Properties["role"].Enum)
+	assert.Nil(validated.Properties["role"].MinLength)
+	assert.Equal(int64(2), *validated.Properties["pair"].MinItems)
+	assert.Equal(int64(2), *validated.Properties["pair"].MaxItems)
+	// the bounds of durations and times are skipped
+	assert.Nil(validated.Properties["ttl"].Minimum)
+	assert.Nil(validated.Properties["expire"].Minimum)
+	// keyed swaggo tag
+	assert.Equal([]string{"_id", "email", "name", "nickname"}, validated.Required)
+	assert.Equal("the nickname", validated.Properties["nickname"].Description)
+	assert.Equal("the id, in uuid", validated.Properties["_id"].Description)
+	assert.Equal("uuid", validated.Properties["_id"].Format)
+	assert.Equal("0b6fa3d4", validated.Properties["_id"].Example)
+	assert.True(validated.Properties["_id"].ReadOnly)
+	assert.Equal("Score", validated.Properties["score"].Title)
+	assert.Equal(float64(10), *validated.Properties["score"].Maximum)
+	assert.True(validated.Properties["score"].ExclusiveMaximum)
+	assert.Equal(0.5, *validated.Properties["score"].MultipleOf)
+	assert.Equal(float64(5), validated.Properties["score"].Default)
+	assert.Equal(7.5, validated.Properties["score"].Example)
+	assert.True(validated.Properties["legacy"].Deprecated)
+	assert.Equal("legacy; use name", validated.Properties["legacy"].Description)
+	assert.Equal([]interface{}{"a", "b"}, validated.Properties["legacy"].Enum)
+
+	// external reference
+	ref := suite.Paths["/testapi/get-struct3"].Post.Responses["500"].Schema.Ref
+	assert.True(strings.HasSuffix(ref, "/test/pkg/api/common.yaml#/definitions/Error"), ref)
 
 	// tags
 	assert.Equal(1, len(suite.Tags))
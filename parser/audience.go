@@ -0,0 +1,57 @@
+package parser
+
+import (
+	"go/ast"
+	"sort"
+	"strings"
+)
+
+// allAudiences generate swagger files for all the audiences in annotations
+const allAudiences = "all"
+
+var (
+	// audience the audience of generating swagger doc,
+	// empty means all the apis and fields are visible
+	audience = ""
+	// audiences the audiences found in annotations
+	audiences = map[string]bool{}
+)
+
+// visibleToAudience check if the api or field is visible to the generating audience
+// s is the list of annotation `@Audience public,partner`
+func visibleToAudience(s string) bool {
+	visible := audience == ""
+	for _, a := range strings.Split(s, ",") {
+		if a = strings.TrimSpace(a); a == "" {
+			continue
+		}
+		audiences[a] = true
+		if a == audience {
+			visible = true
+		}
+	}
+	return visible
+}
+
+// fieldVisibleToAudience check the `@Audience` annotation
+// in the doc or line comment of the struct field
+func fieldVisibleToAudience(f *ast.Field) bool {
+	for _, doc := range []*ast.CommentGroup{f.Doc, f.Comment} {
+		for _, c := range strings.Split(doc.Text(), "\n") {
+			if tagTrimPrefixAndSpace(&c, fieldAudience) && !visibleToAudience(c) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// sortedAudiences the audiences found in annotations in order
+func sortedAudiences() []string {
+	names := make([]string, 0, len(audiences))
+	for name := range audiences {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
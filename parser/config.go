@@ -0,0 +1,158 @@
+package parser
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Config the config file of swaggo (json or yaml)
+type Config struct {
+	// fully-qualified golang type -> swagger type like `string`, `integer,int64`, `array,string`
+	// or the external schema like `$ref:money.yaml#/definitions/Money`
+	Types map[string]string `json:"types" yaml:"types"`
+	// short alias -> content type used by `@Consumes` and `@Produces`, like `jsonapi: application/vnd.api+json`
+	ContentTypes map[string]string `json:"contentTypes" yaml:"contentTypes"`
+}
+
+// typeMappings the swagger types of golang types from config and flags,
+// fully-qualified golang type -> swagger type or external reference(`$ref:` prefix with absolute path)
+var typeMappings = map[string]string{}
+
+// readConfig read the config file, the json file is valid yaml
+func readConfig(filename string) (*Config, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	c := &Config{}
+	if err = yaml.Unmarshal(data, c); err != nil {
+		return nil, fmt.Errorf("parse config file(%s) error(%v)", filename, err)
+	}
+	return c, nil
+}
+
+// loadConfig load the config file of option, returns the empty config if there is none
+func loadConfig(opt *Option) (*Config, error) {
+	if opt.Config == "" {
+		return &Config{}, nil
+	}
+	return readConfig(opt.Config)
+}
+
+// loadTypeMappings load the type mappings of config file and flags,
+// the mappings of flags like `github.com/foo/bar.ObjectID=string` win
+func loadTypeMappings(opt *Option, c *Config) (map[string]string, error) {
+	mappings := map[string]string{}
+	for name, typ := range c.Types {
+		var err error
+		if mappings[name], err = typeMapping(opt.Config, filepath.Dir(opt.Config), name, typ); err != nil {
+			return nil, err
+		}
+	}
+	for _, s := range opt.TypeMappings {
+		idx := strings.Index(s, "=")
+		if idx == -1 {
+			return nil, fmt.Errorf("type mapping(%s) should be like `github.com/foo/bar.ObjectID=string`", s)
+		}
+		name, typ := strings.TrimSpace(s[:idx]), strings.TrimSpace(s[idx+1:])
+		// the external references are relative to the working directory
+		mapping, err := typeMapping("--type-mapping", ".", name, typ)
+		if err != nil {
+			return nil, err
+		}
+		mappings[name] = mapping
+	}
+	return mappings, nil
+}
+
+// typeMapping check the mapping of golang type from the source,
+// the relative file of external reference is resolved by the dir
+func typeMapping(source, dir, name, typ string) (string, error) {
+	if strings.LastIndex(name, ".") <= 0 {
+		return "", fmt.Errorf("type(%s) of mapping should be fully-qualified, like `github.com/foo/bar.ObjectID`", name)
+	}
+	if strings.HasPrefix(typ, externalRefPrefix) {
+		file, pointer := strings.TrimPrefix(typ, externalRefPrefix), ""
+		if idx := strings.Index(file, "#"); idx != -1 {
+			file, pointer = file[:idx], file[idx:]
+		}
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(dir, file)
+		}
+		abs, err := filepath.Abs(file)
+		if err != nil {
+			return "", err
+		}
+		ref, err := externalRef(source, externalRefPrefix+abs+pointer)
+		if err != nil {
+			return "", fmt.Errorf("mapping of type(%s) error(%v)", name, err)
+		}
+		return externalRefPrefix + ref, nil
+	}
+	if _, err := swaggerTypeResult(typ); err != nil {
+		return "", fmt.Errorf("mapping of type(%s) error(%v)", name, err)
+	}
+	return typ, nil
+}
+
+// loadContentTypes load the aliases of content types in config file,
+// the aliases can't shadow the builtin ones
+func loadContentTypes(c *Config) (map[string]string, error) {
+	aliases := map[string]string{}
+	for alias, typ := range c.ContentTypes {
+		if _, ok := contentType[alias]; ok {
+			return nil, fmt.Errorf("content type alias(%s) is builtin", alias)
+		}
+		if strings.Contains(alias, "/") {
+			return nil, fmt.Errorf("content type alias(%s) can't be a media type", alias)
+		}
+		if err := checkMediaType(typ); err != nil {
+			return nil, fmt.Errorf("content type alias(%s) error(%v)", alias, err)
+		}
+		aliases[alias] = typ
+	}
+	return aliases, nil
+}
+
+// mappedType the result of golang type which is mapped by config or flags
+func mappedType(name string) (*result, bool, error) {
+	typ, ok := typeMappings[name]
+	if !ok {
+		return nil, false, nil
+	}
+	if strings.HasPrefix(typ, externalRefPrefix) {
+		return &result{kind: objectKind, ref: strings.TrimPrefix(typ, externalRefPrefix)}, true, nil
+	}
+	r, err := swaggerTypeResult(typ)
+	return r, true, err
+}
+
+// reVersionSuffix the version suffix of import path like `gopkg.in/yaml.v2` or `github.com/foo/bar/v2`
+var reVersionSuffix = regexp.MustCompile(`(\.v\d+|/v\d+)$`)
+
+// qualifiedName the fully-qualified name of schema in the file like `github.com/foo/bar.ObjectID`,
+// the package name is guessed by the import path if it isn't aliased
+func (p *pkg) qualifiedName(filename, schema string) string {
+	expr := strings.Split(schema, ".")
+	if len(expr) != 2 {
+		return p.importPath + "." + schema
+	}
+	if f, ok := p.Files[filename]; ok {
+		for _, im := range f.Imports {
+			importPath := strings.Trim(im.Path.Value, "\"")
+			name := strings.TrimPrefix(filepath.Base(reVersionSuffix.ReplaceAllString(importPath, "")), "go-")
+			if im.Name != nil {
+				name = im.Name.Name
+			}
+			if name == expr[0] {
+				return importPath + "." + expr[1]
+			}
+		}
+	}
+	return schema
+}
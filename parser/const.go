@@ -20,9 +20,23 @@ var contentType = map[string]string{
 	streamType:   "application/octet-stream",
 }
 
+// contentTypeAliases the custom aliases of content types from config
+var contentTypeAliases = map[string]string{}
+
 const (
+	yamlType = "yaml"
 	jsonFile = "swagger.json"
 	yamlFile = "swagger.yaml"
+	// the name of index file listing the split swagger files
+	indexName = "index"
+	// the directory of definitions which are written into separate files
+	definitionsDir = "definitions"
+	swaggerName    = "swagger"
+)
+
+const (
+	splitByTag    = "tag"
+	splitByPrefix = "prefix"
 )
 
 const (
@@ -43,11 +57,13 @@ const (
 	appConsumes          = "@Consumes"
 	appProduces          = "@Produces"
 	// controller tag
-	ctrlPrivate = "@Private"
-	ctrlName    = "@Name"
-	ctrlDesc    = "@Description"
+	ctrlPrivate  = "@Private"
+	ctrlAudience = "@Audience" // @Audience public,partner
+	ctrlName     = "@Name"
+	ctrlDesc     = "@Description"
 	// method tag
 	methodPrivate    = "@Private" // @Private
+	methodAudience   = "@Audience"
 	methodTitle      = "@Title"
 	methodDesc       = "@Description"
 	methodSummary    = "@Summary"
@@ -58,6 +74,15 @@ const (
 	methodConsumes   = "@Consumes"
 	methodProduces   = "@Produces"
 	methodRouter     = "@Router"
+	// the prefix of schema in the external file, like `$ref:common.yaml#/definitions/Error`
+	externalRefPrefix = "$ref:"
+	// the description in the file, like `@Description file(docs/overview.md)`
+	descFilePrefix = "file("
+	// model tag
+	modelName = "@name"
+	modelType = "@swaggertype" // @swaggertype integer,int64
+	// struct field tag
+	fieldAudience = "@Audience"
 )
 
 const (
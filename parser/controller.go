@@ -24,8 +24,11 @@ func (ctrl *controller) parse(s *swagger.Swagger) (err error) {
 		case tagTrimPrefixAndSpace(&c, ctrlName):
 			ctrl.tagName = c
 		case tagTrimPrefixAndSpace(&c, ctrlDesc):
+			if c, err = descriptionFile(ctrl.filename, c); err != nil {
+				return
+			}
 			if tag.Description != "" {
-				tag.Description += "<br>" + c
+				tag.Description += "\n" + c
 			} else {
 				tag.Description = c
 			}
@@ -34,8 +37,15 @@ func (ctrl *controller) parse(s *swagger.Swagger) (err error) {
 			if !devMode {
 				return
 			}
+		case tagTrimPrefixAndSpace(&c, ctrlAudience):
+			if !visibleToAudience(c) {
+				return
+			}
 		}
 	}
+	if tag.Description == "" {
+		tag.Description = docDescription(ctrl.doc)
+	}
 	if ctrl.tagName == "" {
 		if ctrl.noStruct {
 			// TODO
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"go/ast"
 	"log"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -44,23 +45,46 @@ func (m *method) parse(s *swagger.Swagger) (err error) {
 				private = true
 				break
 			}
+		case tagTrimPrefixAndSpace(&c, methodAudience):
+			if !visibleToAudience(c) {
+				private = true
+			}
 		case tagTrimPrefixAndSpace(&c, methodTitle):
 			opt.OperationID = tagName + "." + c
 		case tagTrimPrefixAndSpace(&c, methodDesc):
+			if c, err = descriptionFile(m.filename, c); err != nil {
+				err = m.prettyErr("%v", err)
+				return
+			}
 			if opt.Description != "" {
-				opt.Description += "<br>" + c
+				opt.Description += "\n" + c
 			} else {
 				opt.Description = c
 			}
 		case tagTrimPrefixAndSpace(&c, methodSummary):
 			if opt.Summary != "" {
-				opt.Summary += "<br>" + c
+				// the summary is plain text
+				opt.Summary += " " + c
 			} else {
 				opt.Summary = c
 			}
 		case tagTrimPrefixAndSpace(&c, methodParam):
 			para := swagger.Parameter{}
-			p := getparams(c)
+			p, attrs := splitParamAttrs(getparams(c))
+			if len(p) == 3 && p[0] == expandParamName {
+				// @Param - query ListFilter
+				if _, ok := paramTags[p[1]]; !ok {
+					err = m.prettyErr("struct(%s) can't be expanded into %s params, type must in(query, header, path, form)", p[2], p[1])
+					return
+				}
+				var params []*swagger.Parameter
+				if params, err = m.ctrl.r.expandParams(s, p[1], m.filename, p[2]); err != nil {
+					err = m.prettyErr("expand struct(%s) into %s params error(%v)", p[2], p[1], err)
+					return
+				}
+				opt.Parameters = append(opt.Parameters, params...)
+				continue
+			}
 			if len(p) < 4 {
 				err = m.prettyErr("comments %s shuold have 4 params at least", c)
 				return
@@ -82,7 +106,16 @@ func (m *method) parse(s *swagger.Swagger) (err error) {
 				return
 			}
 			para.In = paramType[p[1]]
-			if err = m.ctrl.r.parseParam(s, &para, m.filename, p[2]); err != nil {
+			var pa *paramAttrs
+			if pa, err = parseParamAttrs(attrs); err != nil {
+				err = m.prettyErr("param(%s) error(%v)", p[0], err)
+				return
+			}
+			if err = m.ctrl.r.parseParam(s, &para, m.filename, p[2], pa.rules); err != nil {
+				return
+			}
+			if err = pa.apply(&para, p[2]); err != nil {
+				err = m.prettyErr("param(%s) error(%v)", p[0], err)
 				return
 			}
 			for idx, v := range p {
@@ -107,9 +140,11 @@ func (m *method) parse(s *swagger.Swagger) (err error) {
 			}
 			opt.Parameters = append(opt.Parameters, &para)
 		case tagTrimPrefixAndSpace(&c, methodSuccess), tagTrimPrefixAndSpace(&c, methodFailure):
+			// @Success 200 Model "description" [media types]
 			sr := &swagger.Response{}
 			p := getparams(c)
 			respCode := ""
+			mediaTypes := []string{}
 			for idx, v := range p {
 				switch idx {
 				case 0:
@@ -122,19 +157,38 @@ func (m *method) parse(s *swagger.Swagger) (err error) {
 						}
 					}
 				case 2:
+					// the description is mandatory before the media types
+					if len(p) == 3 && !strings.Contains(c, `"`) && isMediaTypes(v) {
+						err = m.prettyErr("response(%s) need the description before the media types(%s)", respCode, v)
+						return
+					}
 					sr.Description = v
 				default:
-					err = m.prettyErr("response (%s) format error, need(code, type, description)", c)
-					return
+					// the media types of representation
+					var types []string
+					if types, err = contentTypeByDoc(strings.Trim(v, ",")); err != nil {
+						err = m.prettyErr("response(%s) error(%v)", respCode, err)
+						return
+					}
+					mediaTypes = append(mediaTypes, types...)
 				}
 			}
-			opt.Responses[respCode] = sr
+			if err = addResponse(opt.Responses, respCode, sr, mediaTypes); err != nil {
+				err = m.prettyErr("%v", err)
+				return
+			}
 		case tagTrimPrefixAndSpace(&c, methodDeprecated):
 			opt.Deprecated, _ = strconv.ParseBool(c)
 		case tagTrimPrefixAndSpace(&c, methodConsumes):
-			opt.Consumes = contentTypeByDoc(c)
+			if opt.Consumes, err = contentTypeByDoc(c); err != nil {
+				err = m.prettyErr("%v", err)
+				return
+			}
 		case tagTrimPrefixAndSpace(&c, methodProduces):
-			opt.Produces = contentTypeByDoc(c)
+			if opt.Produces, err = contentTypeByDoc(c); err != nil {
+				err = m.prettyErr("%v", err)
+				return
+			}
 		case tagTrimPrefixAndSpace(&c, methodRouter):
 			// @Router / [post]
 			elements := strings.Split(c, " ")
@@ -150,9 +204,19 @@ func (m *method) parse(s *swagger.Swagger) (err error) {
 			}
 		}
 	}
+	// the free text of doc describes the operation without annotations
+	if desc := docDescription(m.doc); desc != "" {
+		if opt.Description == "" {
+			opt.Description = desc
+		}
+		if opt.Summary == "" {
+			opt.Summary = docSummary(desc)
+		}
+	}
 
 	if routerPath != "" && !private {
 		m.paramCheck(&opt)
+		responseProduces(s, &opt)
 		if s.Paths == nil {
 			s.Paths = map[string]*swagger.Item{}
 		}
@@ -192,6 +256,77 @@ func (m *method) parse(s *swagger.Swagger) (err error) {
 	return
 }
 
+// addResponse add the response of code, the response in media types is one of its representations,
+// the representations of the same code are merged and the response without media types
+// describes the schema and description of them
+func addResponse(responses map[string]*swagger.Response, code string, sr *swagger.Response, mediaTypes []string) error {
+	resp, ok := responses[code]
+	if !ok {
+		resp = &swagger.Response{}
+		responses[code] = resp
+	}
+	if len(mediaTypes) == 0 {
+		resp.Description, resp.Schema = sr.Description, sr.Schema
+		return nil
+	}
+	if resp.Description == "" {
+		resp.Description = sr.Description
+	}
+	if resp.Schema == nil {
+		resp.Schema = sr.Schema
+	}
+	if resp.Content == nil {
+		resp.Content = map[string]*swagger.MediaType{}
+	}
+	for _, t := range mediaTypes {
+		if _, ok := resp.Content[t]; ok {
+			return fmt.Errorf("response(%s) has more than one representation of media type(%s)", code, t)
+		}
+		resp.Content[t] = &swagger.MediaType{Schema: sr.Schema}
+	}
+	return nil
+}
+
+// responseProduces override the produces of operation with the media types of responses,
+// the responses without media types are json if there is no produces
+func responseProduces(s *swagger.Swagger, opt *swagger.Operation) {
+	produces := opt.Produces
+	if len(produces) == 0 {
+		produces = s.Produces
+	}
+	codes := []string{}
+	for code := range opt.Responses {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	missing := []string{}
+	for _, code := range codes {
+		types := []string{}
+		for t := range opt.Responses[code].Content {
+			types = append(types, t)
+		}
+		sort.Strings(types)
+		for _, t := range types {
+			if !subset([]string{t}, append(produces, missing...)) {
+				missing = append(missing, t)
+			}
+		}
+	}
+	if len(missing) == 0 {
+		return
+	}
+	if len(produces) == 0 && !subset([]string{contentType[jsonType]}, missing) {
+		for _, code := range codes {
+			if len(opt.Responses[code].Content) == 0 {
+				// the responses without media types are json by default
+				produces = []string{contentType[jsonType]}
+				break
+			}
+		}
+	}
+	opt.Produces = append(append([]string{}, produces...), missing...)
+}
+
 // paramCheck Verify the validity of parametes
 func (m *method) paramCheck(opt *swagger.Operation) {
 	// swagger ui url (unique)
@@ -4,8 +4,11 @@ import (
 	"errors"
 	"fmt"
 	"go/ast"
+	"log"
 	"reflect"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/teambition/swaggo/swagger"
@@ -23,10 +26,11 @@ const (
 
 // model the type of golang
 type model struct {
-	ast.Expr        // golang ast
-	name     string // the real name of model
-	filename string // appear in which file
-	p        *pkg   // appear in which package
+	ast.Expr                   // golang ast
+	name     string            // the real name of model
+	doc      *ast.CommentGroup // the doc of type declaration
+	filename string            // appear in which file
+	p        *pkg              // appear in which package
 	f        feature
 }
 
@@ -48,6 +52,7 @@ func (m *model) member(e ast.Expr) *model {
 func (m *model) clone(e ast.Expr) *model {
 	nm := *m
 	nm.name = ""
+	nm.doc = nil
 	nm.Expr = e
 	return &nm
 }
@@ -70,6 +75,15 @@ func (m *model) anonymousStruct() *model {
 
 // parse parse the model in go code
 func (m *model) parse(s *swagger.Swagger) (r *result, err error) {
+	if m.name != "" {
+		if r, ok, err := mappedType(m.identity()); ok || err != nil {
+			return r, err
+		}
+		// the type serializes differently from its layout
+		if r, ok, err := m.parseOverride(); ok || err != nil {
+			return r, err
+		}
+	}
 	switch t := m.Expr.(type) {
 	case *ast.StarExpr:
 		return m.clone(t.X).parse(s)
@@ -93,6 +107,10 @@ func (m *model) parse(s *swagger.Swagger) (r *result, err error) {
 		// &{foo Bar} to foo.Bar
 		reInternalRepresentation := regexp.MustCompile("&\\{(\\w*) (\\w*)\\}")
 		schema = string(reInternalRepresentation.ReplaceAll([]byte(schema), []byte("$1.$2")))
+		// check if is mapped by config
+		if r, ok, err := mappedType(m.p.qualifiedName(m.filename, schema)); ok || err != nil {
+			return r, err
+		}
 		// check if is basic type
 		if swaggerType, ok := basicTypes[schema]; ok {
 			tmp := strings.Split(swaggerType, ":")
@@ -134,51 +152,43 @@ func (m *model) parse(s *swagger.Swagger) (r *result, err error) {
 		if m.name == "" {
 			m.anonymousStruct()
 		} else {
-			key = m.name
+			key = m.definitionName()
 			r.title = m.name
 			// find result cache
 			if s.Definitions == nil {
 				s.Definitions = map[string]*swagger.Schema{}
-			} else if ips, ok := cachedModels[m.name]; ok {
-				exsited := false
-				for k, v := range ips {
-					exsited = m.p.importPath == v.path
-					if exsited {
-						if k != 0 {
-							key = fmt.Sprintf("%s_%d", m.name, k)
-						}
-						if m.f == anonMemberFeature {
-							r = v.r
-							return
-						}
-						_, ok := s.Definitions[key]
-						if ok {
-							r.ref = "#/definitions/" + key
-							return
-						}
-						err = fmt.Errorf("the key(%s) must existed in swagger's definitions", key)
-						return
-					}
+			}
+			if v, ok := cachedModels[key]; ok {
+				if v.path != m.identity() {
+					err = fmt.Errorf("definition(%s) of model(%s) conflicts with model(%s), rename it by `%s` or use another naming strategy", key, m.identity(), v.path, modelName)
+					return
 				}
-				if !exsited {
-					ips = append(ips, &kv{m.p.importPath, r})
-					cachedModels[m.name] = ips
-					if len(ips) > 1 {
-						key = fmt.Sprintf("%s_%d", m.name, len(ips)-1)
-					}
+				if m.f == anonMemberFeature {
+					r = v.r
+					return
 				}
-			} else {
-				cachedModels[m.name] = []*kv{&kv{m.p.importPath, r}}
+				if _, ok := s.Definitions[key]; ok {
+					r.ref = "#/definitions/" + key
+					return
+				}
+				err = fmt.Errorf("the key(%s) must existed in swagger's definitions", key)
+				return
 			}
+			cachedModels[key] = &kv{m.identity(), r}
 		}
 
+		// the candidates of json fields, resolved by the rules of encoding/json
+		candidates := map[string][]*jsonField{}
 		for _, f := range t.Fields.List {
+			if !fieldVisibleToAudience(f) {
+				continue
+			}
 			var (
 				childR *result
+				tag    = &fieldTag{}
 				nm     = m.member(f.Type)
-				name   string
+				names  = exportedNames(f.Names)
 			)
-
 			if len(f.Names) == 0 {
 				// anonymous member
 				// type A struct {
@@ -186,53 +196,89 @@ func (m *model) parse(s *swagger.Swagger) (r *result, err error) {
 				//     C
 				// }
 				nm.anonymousMember()
-			} else {
-				name = f.Names[0].Name
+			} else if len(names) == 0 {
+				// the unexported fields are ignored by encoding/json
+				continue
 			}
 
 			if childR, err = nm.parse(s); err != nil {
 				return
 			}
+			// the tags and comments of field describe the copy
+			childR = childR.clone()
 			if f.Tag != nil {
-				var (
-					required bool
-					tmpName  string
-					ignore   bool
-				)
-				if tmpName, childR.desc, childR.def, required, ignore, _ = parseTag(f.Tag.Value, childR.buildin); ignore {
+				if tag, err = parseTag(f.Tag.Value, childR.buildin); err != nil {
+					err = fmt.Errorf("parse tag of field in model(%s) at %s error(%v)", m.name, m.p.fset.Position(f.Pos()), err)
+					return
+				}
+				if tag.ignore {
 					// hanppens when `josn:"-"`
 					continue
 				}
-				if tmpName != "" {
-					name = tmpName
+				if tag.rules != nil {
+					if childR, err = childR.constrain(tag.rules); err != nil {
+						err = fmt.Errorf("parse tag of field in model(%s) at %s error(%v)", m.name, m.p.fset.Position(f.Pos()), err)
+						return
+					}
 				}
-				if required {
-					r.required = append(r.required, name)
+				childR.desc, childR.def = tag.desc, tag.def
+				childR.propTitle, childR.example = tag.title, tag.example
+				childR.readOnly, childR.deprecated = tag.readOnly, tag.deprecated
+				if tag.quoted {
+					childR = childR.quoted()
 				}
 			}
+			if childR.desc == "" && len(f.Names) != 0 {
+				// the doc or line comment of field
+				childR.desc = docDescription(f.Doc, f.Comment)
+			}
 
-			// must as a anonymous struct
-			if nm.f == anonMemberFeature {
-				hasKey := false
-				for k1, v1 := range childR.items {
-					for k2 := range r.items {
-						if k1 == k2 {
-							hasKey = true
-							break
-						}
-					}
-					if !hasKey {
-						r.items[k1] = v1
-						for _, v := range childR.required {
-							if v == k1 {
-								r.required = append(r.required, v)
-								break
-							}
-						}
+			if len(f.Names) == 0 {
+				if tag.name == "" && childR.kind == objectKind && childR.fields != nil {
+					// promote the fields of embedded struct
+					for name, field := range childR.fields {
+						candidates[name] = append(candidates[name], &jsonField{
+							r:        field.r,
+							depth:    field.depth + 1,
+							tagged:   field.tagged,
+							required: field.required,
+						})
 					}
+					continue
 				}
-			} else {
-				r.items[name] = childR
+				// the embedded non-struct type is a field named by its type
+				if names = exportedNames([]*ast.Ident{embeddedName(f.Type)}); len(names) == 0 && tag.name == "" {
+					continue
+				}
+			}
+			if tag.name != "" {
+				names = []string{tag.name}
+			}
+			if tag.required && tag.omitEmpty {
+				log.Println("[Warning]", fmt.Sprintf("field(%s) of model(%s) with `omitempty` may be omitted, it isn't required", strings.Join(names, ","), m.name))
+			}
+			for _, name := range names {
+				candidates[name] = append(candidates[name], &jsonField{
+					r:        childR,
+					tagged:   tag.name != "",
+					required: tag.required && !tag.omitEmpty,
+				})
+			}
+		}
+
+		r.fields = map[string]*jsonField{}
+		for name, fields := range candidates {
+			field := dominantField(fields)
+			r.fields[name] = field
+			if field.r == nil {
+				if len(fields) > 1 {
+					log.Println("[Warning]", fmt.Sprintf("field(%s) of model(%s) is ambiguous and ignored like encoding/json", name, m.name))
+				}
+				continue
+			}
+			r.items[name] = field.r
+			if field.required {
+				r.required = append(r.required, name)
 			}
 		}
 
@@ -242,6 +288,7 @@ func (m *model) parse(s *swagger.Swagger) (r *result, err error) {
 			if err != nil {
 				return nil, err
 			}
+			ss.Description = docDescription(m.doc)
 			s.Definitions[key] = ss
 			if m.f != anonMemberFeature {
 				r.ref = "#/definitions/" + key
@@ -253,14 +300,113 @@ func (m *model) parse(s *swagger.Swagger) (r *result, err error) {
 
 // cachedModels the cache of models
 // Format:
-//   model name -> import path and result
-var cachedModels = map[string][]*kv{}
+//
+//	definition name -> the identity of model and result
+var cachedModels = map[string]*kv{}
 
 type kv struct {
 	path string
 	r    *result
 }
 
+// naming strategies of definitions
+const (
+	simpleNaming     = "simple"     // User
+	packageNaming    = "package"    // user.User
+	underscoreNaming = "underscore" // user_User
+	pathNaming       = "path"       // github.com/teambition/user.User
+)
+
+var namingStrategy = simpleNaming
+
+// identity the unique name of model
+func (m *model) identity() string {
+	return m.p.importPath + "." + m.name
+}
+
+// docTag find the annotation in the doc of type declaration
+func (m *model) docTag(tag string) (string, bool) {
+	if m.doc != nil {
+		for _, c := range strings.Split(m.doc.Text(), "\n") {
+			if tagTrimPrefixAndSpace(&c, tag) && c != "" {
+				return c, true
+			}
+		}
+	}
+	return "", false
+}
+
+// parseOverride parse the type which is described by `// @swaggertype` annotation,
+// or implements json.Marshaler or encoding.TextMarshaler
+func (m *model) parseOverride() (*result, bool, error) {
+	if c, ok := m.docTag(modelType); ok {
+		r, err := swaggerTypeResult(c)
+		if err != nil {
+			return nil, true, fmt.Errorf("model(%s) has invalid annotation(%s %s) error(%v)", m.identity(), modelType, c, err)
+		}
+		return r, true, nil
+	}
+	methods := m.p.methods(m.name)
+	switch {
+	case methods["MarshalJSON"]:
+		log.Println("[Warning]", fmt.Sprintf("model(%s) implements json.Marshaler, describe it by `%s`", m.identity(), modelType))
+		return &result{kind: interfaceKind}, true, nil
+	case methods["MarshalText"]:
+		return &result{kind: innerKind, buildin: "string", sType: "string"}, true, nil
+	}
+	return nil, false, nil
+}
+
+// swaggerTypeResult parse the swagger type like `string`, `integer,int64` or `array,string`
+func swaggerTypeResult(s string) (*result, error) {
+	tmp := strings.Split(strings.Replace(s, " ", "", -1), ",")
+	switch tmp[0] {
+	case "array":
+		if len(tmp) == 1 {
+			return nil, errors.New("array need the type of items")
+		}
+		item, err := swaggerTypeResult(strings.Join(tmp[1:], ","))
+		if err != nil {
+			return nil, err
+		}
+		return &result{kind: arrayKind, item: item}, nil
+	case "object":
+		return &result{kind: interfaceKind}, nil
+	case "string", "integer", "number", "boolean":
+		r := &result{kind: innerKind, buildin: swaggerBuildin[tmp[0]], sType: tmp[0]}
+		if len(tmp) > 1 {
+			r.sFormat = tmp[1]
+		}
+		return r, nil
+	}
+	return nil, fmt.Errorf("unknown type(%s), only support in (string, integer, number, boolean, object, array)", tmp[0])
+}
+
+// swaggerBuildin the golang type of swagger type for parsing the default value
+var swaggerBuildin = map[string]string{
+	"string":  "string",
+	"integer": "int64",
+	"number":  "float64",
+	"boolean": "bool",
+}
+
+// definitionName the name of model in swagger's definitions,
+// it can be overridden by `// @name` annotation of the struct
+func (m *model) definitionName() string {
+	if name, ok := m.docTag(modelName); ok {
+		return name
+	}
+	switch namingStrategy {
+	case packageNaming:
+		return m.p.Name + "." + m.name
+	case underscoreNaming:
+		return m.p.Name + "_" + m.name
+	case pathNaming:
+		return m.identity()
+	}
+	return m.name
+}
+
 type kind int
 
 const (
@@ -284,40 +430,325 @@ type result struct {
 	item     *result
 	required []string
 	items    map[string]*result
+	fields   map[string]*jsonField // the fields of struct including the ambiguous ones
+	// the validation keywords from the tags of validator libraries
+	validation swagger.Validation
+	// the attributes of property from the swaggo tag
+	propTitle  string
+	example    interface{}
+	readOnly   bool
+	deprecated bool
 }
 
-func parseTag(tagStr, buildin string) (name, desc string, def interface{}, required, ignore bool, err error) {
+// fieldTag the tags of struct field
+type fieldTag struct {
+	name       string // the name in json
+	omitEmpty  bool   // `json:",omitempty"`
+	quoted     bool   // `json:",string"`
+	ignore     bool   // `json:"-"`
+	required   bool
+	desc       string
+	def        interface{}
+	title      string
+	example    interface{}
+	readOnly   bool
+	deprecated bool
+	rules      *fieldRules // the rules of validator libraries and swaggo tag
+}
+
+func parseTag(tagStr, buildin string) (tag *fieldTag, err error) {
+	tag = &fieldTag{}
 	// parse tag for name
 	stag := reflect.StructTag(strings.Trim(tagStr, "`"))
 	// check jsonTag == "-"
+	// the other tags are still parsed for the params named by `form`, `query`... tags
 	jsonTag := strings.Split(stag.Get("json"), ",")
-	if len(jsonTag) != 0 {
-		if jsonTag[0] == "-" {
-			ignore = true
-			return
+	if jsonTag[0] == "-" && len(jsonTag) == 1 {
+		tag.ignore = true
+	} else {
+		tag.name = jsonTag[0]
+		for _, opt := range jsonTag[1:] {
+			switch opt {
+			case "omitempty":
+				tag.omitEmpty = true
+			case "string":
+				tag.quoted = true
+			}
 		}
-		name = jsonTag[0]
 	}
-	// swaggo:"(required),(desc),(default)"
+	// validate:"required,min=1,max=100"
+	if tag.rules, err = parseValidatorTags(stag); err != nil {
+		return
+	}
 	swaggoTag := stag.Get("swaggo")
-	tmp := strings.Split(swaggoTag, ",")
-	for k, v := range tmp {
-		switch k {
-		case 0:
-			if v == "true" {
-				required = true
-			}
-		case 1:
-			desc = v
-		case 2:
-			if v != "" {
-				def, err = str2RealType(v, buildin)
+	if isKeyedTag(swaggoTag) {
+		// swaggo:"required;desc=...;default=..."
+		if err = tag.parseKeyed(swaggoTag, buildin); err != nil {
+			return
+		}
+	} else {
+		// swaggo:"(required),(desc),(default)"
+		tmp := strings.Split(swaggoTag, ",")
+		for k, v := range tmp {
+			switch k {
+			case 0:
+				if v == "true" {
+					tag.required = true
+				}
+			case 1:
+				tag.desc = v
+			case 2:
+				if v != "" {
+					if tag.def, err = str2RealType(v, buildin); err != nil {
+						return
+					}
+				}
 			}
 		}
 	}
+	// the required of validator libraries
+	if tag.rules != nil && tag.rules.required {
+		tag.required = true
+	}
+	return
+}
+
+// swaggoTagKeys the keys of attributes of keyed swaggo tag
+var swaggoTagKeys = map[string]bool{
+	"required": true, "readonly": true, "readOnly": true, "deprecated": true,
+	"unique": true, "uniqueItems": true, "exclusiveMinimum": true, "exclusiveMaximum": true,
+	"desc": true, "description": true, "title": true, "default": true, "example": true,
+	"enum": true, "format": true, "pattern": true, "multipleOf": true,
+	"min": true, "minimum": true, "minLength": true, "minItems": true,
+	"max": true, "maximum": true, "maxLength": true, "maxItems": true,
+}
+
+// isKeyedTag check if the swaggo tag is the keyed syntax like `required;desc=...` or `desc=...`,
+// the first attribute of keyed syntax is a known key or `key=value`,
+// otherwise it's the positional syntax whose description may have `;` or `=`
+func isKeyedTag(s string) bool {
+	first := strings.TrimSpace(splitEscaped(s, ';')[0])
+	if swaggoTagKeys[first] {
+		return true
+	}
+	idx := strings.Index(first, "=")
+	return idx != -1 && !strings.Contains(first[:idx], ",")
+}
+
+// parseKeyed parse the keyed swaggo tag like
+// `required;desc=...;default=...;example=...;enum=a|b;format=uuid;readonly;deprecated;min=0`,
+// the attributes are separated by `;` which is escaped by `\;`
+func (tag *fieldTag) parseKeyed(s, buildin string) (err error) {
+	if tag.rules == nil {
+		tag.rules = &fieldRules{}
+	}
+	rules := tag.rules
+	for _, attr := range splitEscaped(s, ';') {
+		if attr = strings.TrimSpace(attr); attr == "" {
+			continue
+		}
+		key, value, hasValue := attr, "", false
+		if idx := strings.Index(attr, "="); idx != -1 {
+			key, value, hasValue = strings.TrimSpace(attr[:idx]), strings.TrimSpace(attr[idx+1:]), true
+		}
+		switch key {
+		case "required":
+			tag.required, err = flagValue(attr, value, hasValue)
+		case "readonly", "readOnly":
+			tag.readOnly, err = flagValue(attr, value, hasValue)
+		case "deprecated":
+			tag.deprecated, err = flagValue(attr, value, hasValue)
+		case "unique", "uniqueItems":
+			rules.unique, err = flagValue(attr, value, hasValue)
+		case "exclusiveMinimum":
+			rules.exclusiveMin, err = flagValue(attr, value, hasValue)
+		case "exclusiveMaximum":
+			rules.exclusiveMax, err = flagValue(attr, value, hasValue)
+		case "desc", "description":
+			tag.desc = value
+		case "title":
+			tag.title = value
+		case "default":
+			tag.def, err = str2RealType(value, buildin)
+		case "example":
+			tag.example, err = str2RealType(value, buildin)
+		case "enum":
+			rules.enum = strings.Split(value, "|")
+		case "format":
+			rules.format = value
+		case "pattern":
+			rules.pattern = value
+		case "min", "minimum", "minLength", "minItems":
+			rules.min, err = ruleBound(attr, value)
+		case "max", "maximum", "maxLength", "maxItems":
+			rules.max, err = ruleBound(attr, value)
+		case "multipleOf":
+			rules.multipleOf, err = ruleBound(attr, value)
+		default:
+			err = fmt.Errorf("unknown attribute(%s) of swaggo tag", key)
+		}
+		if err != nil {
+			return
+		}
+	}
 	return
 }
 
+// flagValue the value of flag attribute, `readonly` is the same as `readonly=true`
+func flagValue(attr, value string, hasValue bool) (bool, error) {
+	if !hasValue {
+		return true, nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("attribute(%s) need a bool", attr)
+	}
+	return b, nil
+}
+
+// splitEscaped split the string by sep which isn't escaped by `\`
+func splitEscaped(s string, sep byte) []string {
+	items := []string{}
+	item := []byte{}
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '\\' && i+1 < len(s) && s[i+1] == sep:
+			item = append(item, sep)
+			i++
+		case s[i] == sep:
+			items = append(items, string(item))
+			item = item[:0]
+		default:
+			item = append(item, s[i])
+		}
+	}
+	return append(items, string(item))
+}
+
+// exportedNames the exported names of struct fields
+func exportedNames(idents []*ast.Ident) []string {
+	names := []string{}
+	for _, ident := range idents {
+		if ident != nil && ident.IsExported() {
+			names = append(names, ident.Name)
+		}
+	}
+	return names
+}
+
+// embeddedName the name of embedded field which is the name of type
+func embeddedName(e ast.Expr) *ast.Ident {
+	switch t := e.(type) {
+	case *ast.Ident:
+		return t
+	case *ast.StarExpr:
+		return embeddedName(t.X)
+	case *ast.SelectorExpr:
+		return t.Sel
+	}
+	return nil
+}
+
+// jsonField the field of struct in json
+type jsonField struct {
+	r        *result // nil if the field is ambiguous
+	depth    int     // the depth of embedded struct
+	tagged   bool    // named by json tag
+	required bool
+}
+
+// dominantField find the dominant field in the fields with the same name like encoding/json:
+// the shallowest one wins, then the only tagged one of them,
+// otherwise the fields annihilate each other
+func dominantField(fields []*jsonField) *jsonField {
+	depth := fields[0].depth
+	for _, f := range fields {
+		if f.depth < depth {
+			depth = f.depth
+		}
+	}
+	var dominant, tagged []*jsonField
+	for _, f := range fields {
+		if f.depth == depth {
+			dominant = append(dominant, f)
+			if f.tagged {
+				tagged = append(tagged, f)
+			}
+		}
+	}
+	switch {
+	case len(dominant) == 1:
+		return dominant[0]
+	case len(tagged) == 1:
+		return tagged[0]
+	}
+	return &jsonField{depth: depth}
+}
+
+// clone deep copy the result, the results of models are shared by the cache
+// and must not be changed by the tags of fields
+func (r *result) clone() *result {
+	return r.cloneVisited(map[*result]*result{})
+}
+
+func (r *result) cloneVisited(visited map[*result]*result) *result {
+	if r == nil {
+		return nil
+	}
+	if q, ok := visited[r]; ok {
+		return q
+	}
+	q := *r
+	visited[r] = &q
+	q.item = r.item.cloneVisited(visited)
+	if r.items != nil {
+		q.items = make(map[string]*result, len(r.items))
+		for name, item := range r.items {
+			q.items[name] = item.cloneVisited(visited)
+		}
+	}
+	if r.fields != nil {
+		q.fields = make(map[string]*jsonField, len(r.fields))
+		for name, field := range r.fields {
+			f := *field
+			f.r = field.r.cloneVisited(visited)
+			q.fields[name] = &f
+		}
+	}
+	q.required = append([]string(nil), r.required...)
+	q.validation.Enum = append([]interface{}(nil), r.validation.Enum...)
+	return &q
+}
+
+// quoted the result of `json:",string"` option which encodes the scalar value as string,
+// the format is kept to describe the value in string
+func (r *result) quoted() *result {
+	switch r.sType {
+	case "integer", "number", "boolean":
+	default:
+		return r
+	}
+	q := *r
+	q.sType = "string"
+	if q.def != nil {
+		q.def = fmt.Sprint(q.def)
+	}
+	if q.example != nil {
+		q.example = fmt.Sprint(q.example)
+	}
+	// the bounds of numbers don't apply to strings
+	q.validation.Minimum, q.validation.Maximum, q.validation.MultipleOf = nil, nil, nil
+	q.validation.ExclusiveMinimum, q.validation.ExclusiveMaximum = false, false
+	if len(q.validation.Enum) != 0 {
+		enum := []interface{}{}
+		for _, e := range q.validation.Enum {
+			enum = append(enum, fmt.Sprint(e))
+		}
+		q.validation.Enum = enum
+	}
+	return &q
+}
+
 func (r *result) convertToSchema() (*swagger.Schema, error) {
 	ss := &swagger.Schema{}
 	switch r.kind {
@@ -331,9 +762,8 @@ func (r *result) convertToSchema() (*swagger.Schema, error) {
 
 func (r *result) parseSchema(ss *swagger.Schema) {
 	ss.Title = r.title
-	// NOTE:
-	// schema description not support now
-	// ss.Description = r.desc
+	ss.Description = r.desc
+	ss.Validation = r.validation
 	switch r.kind {
 	case innerKind:
 		ss.Type = r.sType
@@ -345,7 +775,7 @@ func (r *result) parseSchema(ss *swagger.Schema) {
 			ss.Ref = r.ref
 			return
 		}
-		ss.Required = r.required
+		ss.Required = sortedRequired(r.required)
 		if ss.Properties == nil {
 			ss.Properties = make(map[string]*swagger.Propertie)
 		}
@@ -367,8 +797,23 @@ func (r *result) parseSchema(ss *swagger.Schema) {
 	}
 }
 
+// sortedRequired the required properties in stable order
+func sortedRequired(required []string) []string {
+	if len(required) == 0 {
+		return nil
+	}
+	r := append([]string{}, required...)
+	sort.Strings(r)
+	return r
+}
+
 func (r *result) parsePropertie(sp *swagger.Propertie) {
 	sp.Description = r.desc
+	sp.Validation = r.validation
+	sp.Title = r.propTitle
+	sp.Example = r.example
+	sp.ReadOnly = r.readOnly
+	sp.Deprecated = r.deprecated
 	switch r.kind {
 	case innerKind:
 		sp.Default = r.def
@@ -388,7 +833,7 @@ func (r *result) parsePropertie(sp *swagger.Propertie) {
 			sp.Ref = r.ref
 			return
 		}
-		sp.Required = r.required
+		sp.Required = sortedRequired(r.required)
 		if sp.Properties == nil {
 			sp.Properties = make(map[string]*swagger.Propertie)
 		}
@@ -411,6 +856,7 @@ func (r *result) parseParam(sp *swagger.Parameter) error {
 		}
 		r.parseSchema(sp.Schema)
 	default:
+		sp.Validation = r.validation
 		switch r.kind {
 		case innerKind:
 			sp.Type = r.sType
@@ -431,6 +877,7 @@ func (r *result) parseParam(sp *swagger.Parameter) error {
 }
 
 func (r *result) parseParamItem(sp *swagger.ParameterItems) error {
+	sp.Validation = r.validation
 	switch r.kind {
 	case innerKind:
 		sp.Type = r.sType
@@ -472,7 +919,20 @@ var basicTypes = map[string]string{
 	"rune":       "string:byte",
 	"time.Time":  "string:date-time",
 	"file":       "file:",
-	// option.XXX from code.teambition.com/soa/go-lib/pkg/option
+	// the well-known types of stdlib and popular packages
+	"time.Duration":      "integer:int64",
+	"json.RawMessage":    "object:",
+	"json.Number":        "number:",
+	"net.IP":             "string:",
+	"big.Int":            "integer:",
+	"big.Float":          "string:",
+	"big.Rat":            "string:",
+	"uuid.UUID":          "string:uuid",
+	"decimal.Decimal":    "string:",
+	"bson.ObjectId":      "string:",
+	"primitive.ObjectID": "string:",
+	// option.XXX from code.teambition.com/soa/go-lib/pkg/option,
+	// the type mappings of config and flags override them
 	"option.Interface": "object:",
 	"option.ObjectID":  "string:",
 	"option.ObjectIDs": "[]string:",
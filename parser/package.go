@@ -8,6 +8,7 @@ import (
 	"go/token"
 	"log"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/teambition/swaggo/swagger"
@@ -18,12 +19,15 @@ type pkg struct {
 	localName  string // alias name of package include "."
 	importPath string // the import package name
 	absPath    string // whereis package in filesystem
+	fset       *token.FileSet
 	vendor     string // project vendor for lookup package
 	// filename -> import pkgs
 	importPkgs map[string][]*pkg
 	// model name -> model
 	// cache of model struct
 	models []*model
+	// type name -> the names of methods
+	typeMethods map[string]map[string]bool
 }
 
 // newPackage
@@ -41,7 +45,8 @@ func newPackage(localName, importPath string, justGoPath bool) (p *pkg, err erro
 		err = fmt.Errorf("package(%s) does not existed", importPath)
 		return
 	}
-	pkgs, err := parser.ParseDir(token.NewFileSet(), absPath, func(info os.FileInfo) bool {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, absPath, func(info os.FileInfo) bool {
 		name := info.Name()
 		return !info.IsDir() &&
 			!strings.HasPrefix(name, ".") &&
@@ -63,6 +68,7 @@ func newPackage(localName, importPath string, justGoPath bool) (p *pkg, err erro
 			importPath: importPath,
 			vendor:     vendor,
 			absPath:    absPath,
+			fset:       fset,
 			importPkgs: map[string][]*pkg{},
 			models:     []*model{},
 		}, nil
@@ -72,6 +78,10 @@ func newPackage(localName, importPath string, justGoPath bool) (p *pkg, err erro
 
 // parseSchema Parse schema in this code file
 func (p *pkg) parseSchema(s *swagger.Swagger, ss *swagger.Schema, filename, schema string) (err error) {
+	if strings.HasPrefix(schema, externalRefPrefix) {
+		ss.Ref, err = externalRef(filename, schema)
+		return
+	}
 	r, err := newModel(filename, ast.NewIdent(schema), p).parse(s)
 	if err != nil {
 		return err
@@ -80,15 +90,49 @@ func (p *pkg) parseSchema(s *swagger.Swagger, ss *swagger.Schema, filename, sche
 	return
 }
 
-// parseParam Parse param in this code file
-func (p *pkg) parseParam(s *swagger.Swagger, sp *swagger.Parameter, filename, schema string) (err error) {
+// parseParam Parse param in this code file, constrained by the rules if any
+func (p *pkg) parseParam(s *swagger.Swagger, sp *swagger.Parameter, filename, schema string, rules *fieldRules) (err error) {
+	if strings.HasPrefix(schema, externalRefPrefix) {
+		if sp.In != paramType[body] {
+			return fmt.Errorf("external reference(%s) is only supported by body param(%s)", schema, sp.Name)
+		}
+		sp.Schema = &swagger.Schema{}
+		sp.Schema.Ref, err = externalRef(filename, schema)
+		return
+	}
 	r, err := newModel(filename, ast.NewIdent(schema), p).parse(s)
 	if err != nil {
 		return err
 	}
+	if rules != nil {
+		if r, err = r.constrain(rules); err != nil {
+			return err
+		}
+	}
 	return r.parseParam(sp)
 }
 
+// externalRef load and validate the schema referenced by `$ref:file#/pointer`,
+// the file is relative to the code file, returns the absolute reference
+func externalRef(filename, schema string) (string, error) {
+	file, pointer := strings.TrimPrefix(schema, externalRefPrefix), ""
+	if idx := strings.Index(file, "#"); idx != -1 {
+		file, pointer = file[:idx], file[idx:]
+	}
+	if !filepath.IsAbs(file) {
+		file = filepath.Join(filepath.Dir(filename), file)
+	}
+	ref := filepath.ToSlash(file) + pointer
+	v, err := swagger.ReadRef(ref)
+	if err != nil {
+		return "", fmt.Errorf("load schema(%s) in file(%s) error(%v)", schema, filename, err)
+	}
+	if errs := swagger.ValidateSchema(v); len(errs) != 0 {
+		return "", fmt.Errorf("invalid schema(%s) in file(%s) error(%v)", schema, filename, errs[0])
+	}
+	return ref, nil
+}
+
 // parseImports parse packages from file
 // when the qualified identifier has package name
 // or cann't be find in self(imported with `.`)
@@ -157,6 +201,7 @@ func (p *pkg) findModel(modelName string) (*model, error) {
 				if ok {
 					m := &model{
 						name:     name,
+						doc:      typeDoc(f, ts),
 						filename: filename,
 						p:        p,
 						Expr:     ts.Type,
@@ -171,6 +216,45 @@ func (p *pkg) findModel(modelName string) (*model, error) {
 	return nil, errModelNotFound
 }
 
+// methods the names of methods declared on the type or its pointer
+func (p *pkg) methods(typeName string) map[string]bool {
+	if p.typeMethods == nil {
+		p.typeMethods = map[string]map[string]bool{}
+		for _, f := range p.Files {
+			for _, d := range f.Decls {
+				fd, ok := d.(*ast.FuncDecl)
+				if !ok || fd.Recv == nil || len(fd.Recv.List) == 0 {
+					continue
+				}
+				recv := fd.Recv.List[0].Type
+				if star, ok := recv.(*ast.StarExpr); ok {
+					recv = star.X
+				}
+				if ident, ok := recv.(*ast.Ident); ok {
+					if p.typeMethods[ident.Name] == nil {
+						p.typeMethods[ident.Name] = map[string]bool{}
+					}
+					p.typeMethods[ident.Name][fd.Name.Name] = true
+				}
+			}
+		}
+	}
+	return p.typeMethods[typeName]
+}
+
+// typeDoc find the doc of type declaration
+func typeDoc(f *ast.File, ts *ast.TypeSpec) *ast.CommentGroup {
+	if ts.Doc != nil {
+		return ts.Doc
+	}
+	for _, d := range f.Decls {
+		if gd, ok := d.(*ast.GenDecl); ok && len(gd.Specs) == 1 && gd.Specs[0] == ts {
+			return gd.Doc
+		}
+	}
+	return nil
+}
+
 func (p *pkg) findModelBySchema(filename, schema string) (model *model, err error) {
 	expr := strings.Split(schema, ".")
 	switch len(expr) {
@@ -0,0 +1,332 @@
+package parser
+
+import (
+	"fmt"
+	"go/ast"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/teambition/swaggo/swagger"
+)
+
+// the name of param which expands the fields of struct into parameters,
+// like `@Param - query ListFilter`
+const expandParamName = "-"
+
+// the nesting styles of the expanded parameters
+const (
+	dotNesting     = "dot"     // filter.name
+	bracketNesting = "bracket" // filter[name]
+)
+
+var paramNesting = dotNesting
+
+// paramTags the struct tags naming the parameters in order, the `json` tag is the fallback
+var paramTags = map[string][]string{
+	query:  {"query", "form"},
+	header: {"header"},
+	path:   {"uri", "path"},
+	form:   {"form"},
+}
+
+// paramAttrs the trailing attributes of `@Param`,
+// like `enum(asc,desc)`, `minimum(1)`, `collectionFormat(multi)` and `deprecated`
+type paramAttrs struct {
+	rules            *fieldRules
+	collectionFormat string
+	allowEmptyValue  bool
+	example          string
+	deprecated       bool
+}
+
+// paramAttrKeys the keys of attributes with value like `minimum(1)`
+var paramAttrKeys = map[string]bool{
+	"enum":             true,
+	"minimum":          true,
+	"maximum":          true,
+	"minLength":        true,
+	"maxLength":        true,
+	"minItems":         true,
+	"maxItems":         true,
+	"multipleOf":       true,
+	"pattern":          true,
+	"format":           true,
+	"collectionFormat": true,
+	"example":          true,
+}
+
+// paramFlags the attributes without value like `deprecated`
+var paramFlags = map[string]bool{
+	"allowEmptyValue":  true,
+	"deprecated":       true,
+	"uniqueItems":      true,
+	"exclusiveMinimum": true,
+	"exclusiveMaximum": true,
+}
+
+// collectionFormats the valid collection formats of array params
+var collectionFormats = []string{"csv", "ssv", "tsv", "pipes", "multi"}
+
+var reParamAttr = regexp.MustCompile(`^(\w+)\((.*)\)$`)
+
+// splitParamAttrs split the elements of `@Param` into the positional ones and the attributes,
+// the attributes follow the name, type and data type of param
+func splitParamAttrs(elements []string) ([]string, []string) {
+	positional, attrs := []string{}, []string{}
+	for i, s := range elements {
+		if i >= 3 && isParamAttr(s) {
+			attrs = append(attrs, s)
+		} else {
+			positional = append(positional, s)
+		}
+	}
+	return positional, attrs
+}
+
+// isParamAttr check if the element of `@Param` is an attribute
+func isParamAttr(s string) bool {
+	if paramFlags[s] {
+		return true
+	}
+	sm := reParamAttr.FindStringSubmatch(s)
+	return sm != nil && paramAttrKeys[sm[1]]
+}
+
+// parseParamAttrs parse the attributes of `@Param`
+func parseParamAttrs(attrs []string) (*paramAttrs, error) {
+	pa := &paramAttrs{}
+	if len(attrs) == 0 {
+		return pa, nil
+	}
+	pa.rules = &fieldRules{}
+	for _, s := range attrs {
+		key, value := s, ""
+		if sm := reParamAttr.FindStringSubmatch(s); sm != nil {
+			key, value = sm[1], sm[2]
+		}
+		var err error
+		switch key {
+		case "enum":
+			for _, e := range strings.Split(value, ",") {
+				pa.rules.enum = append(pa.rules.enum, strings.TrimSpace(e))
+			}
+		case "minimum", "minLength", "minItems":
+			pa.rules.min, err = ruleBound(s, value)
+		case "maximum", "maxLength", "maxItems":
+			pa.rules.max, err = ruleBound(s, value)
+		case "multipleOf":
+			pa.rules.multipleOf, err = ruleBound(s, value)
+		case "exclusiveMinimum":
+			pa.rules.exclusiveMin = true
+		case "exclusiveMaximum":
+			pa.rules.exclusiveMax = true
+		case "uniqueItems":
+			pa.rules.unique = true
+		case "pattern":
+			pa.rules.pattern = value
+		case "format":
+			pa.rules.format = value
+		case "collectionFormat":
+			pa.collectionFormat = value
+			if !subset([]string{value}, collectionFormats) {
+				err = fmt.Errorf("unknown collectionFormat(%s), only support in (%s)", value, strings.Join(collectionFormats, ", "))
+			}
+		case "allowEmptyValue":
+			pa.allowEmptyValue = true
+		case "example":
+			pa.example = value
+		case "deprecated":
+			pa.deprecated = true
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return pa, nil
+}
+
+// apply the attributes which aren't validation keywords to the param of data type
+func (pa *paramAttrs) apply(sp *swagger.Parameter, typ string) (err error) {
+	if sp.In == paramType[body] {
+		if pa.collectionFormat != "" || pa.allowEmptyValue || pa.example != "" || pa.deprecated {
+			return fmt.Errorf("body param only supports the validation attributes")
+		}
+		return nil
+	}
+	if pa.collectionFormat != "" {
+		if sp.Type != "array" {
+			return fmt.Errorf("collectionFormat(%s) is only for array param", pa.collectionFormat)
+		}
+		if pa.collectionFormat == "multi" && sp.In != paramType[query] && sp.In != paramType[form] {
+			return fmt.Errorf("collectionFormat(multi) is only for query or form param")
+		}
+		sp.CollectionFormat = pa.collectionFormat
+	}
+	if pa.allowEmptyValue {
+		if sp.In != paramType[query] && sp.In != paramType[form] {
+			return fmt.Errorf("allowEmptyValue is only for query or form param")
+		}
+		sp.AllowEmptyValue = true
+	}
+	if pa.example != "" {
+		if sp.Example, err = str2RealType(pa.example, typ); err != nil {
+			return fmt.Errorf("example(%s) of type(%s) error(%v)", pa.example, typ, err)
+		}
+	}
+	sp.Deprecated = pa.deprecated
+	return nil
+}
+
+// paramExpander expand the fields of struct into parameters
+type paramExpander struct {
+	s        *swagger.Swagger
+	in       string
+	visiting map[string]bool // the identities of structs being expanded
+}
+
+// expandParams expand the fields of struct schema into the parameters in query, header, path or form,
+// the nested structs are flattened with dotted or bracketed names
+func (p *pkg) expandParams(s *swagger.Swagger, in, filename, schema string) ([]*swagger.Parameter, error) {
+	e := &paramExpander{s: s, in: in, visiting: map[string]bool{}}
+	return e.expand(newModel(filename, ast.NewIdent(schema), p), "", true)
+}
+
+// expand the fields of struct model with the prefix of names,
+// the fields of nested struct are required only if the struct is required,
+// the path params are always required
+func (e *paramExpander) expand(m *model, prefix string, required bool) ([]*swagger.Parameter, error) {
+	sm, st, err := m.structType()
+	if err != nil {
+		return nil, err
+	}
+	if st == nil {
+		return nil, fmt.Errorf("schema(%s) of %s params should be a struct", m.Expr, e.in)
+	}
+	if sm.name != "" {
+		if e.visiting[sm.identity()] {
+			return nil, fmt.Errorf("model(%s) can't be expanded into %s params recursively", sm.identity(), e.in)
+		}
+		e.visiting[sm.identity()] = true
+		defer delete(e.visiting, sm.identity())
+	}
+
+	params := []*swagger.Parameter{}
+	for _, f := range st.Fields.List {
+		if !fieldVisibleToAudience(f) {
+			continue
+		}
+		names := exportedNames(f.Names)
+		if len(f.Names) != 0 && len(names) == 0 {
+			continue
+		}
+		nm := sm.member(f.Type)
+		childR, err := nm.parse(e.s)
+		if err != nil {
+			return nil, err
+		}
+		tag := &fieldTag{}
+		name := ""
+		if f.Tag != nil {
+			if tag, err = parseTag(f.Tag.Value, childR.buildin); err != nil {
+				return nil, fmt.Errorf("parse tag of field in model(%s) at %s error(%v)", sm.name, sm.p.fset.Position(f.Pos()), err)
+			}
+			name = e.paramName(reflect.StructTag(strings.Trim(f.Tag.Value, "`")))
+			if name == "-" {
+				continue
+			}
+		}
+		if len(f.Names) == 0 {
+			if name == "" && childR.kind == objectKind {
+				// flatten the embedded struct, the embedded pointer may be nil
+				_, isPtr := f.Type.(*ast.StarExpr)
+				embedded, err := e.expand(nm, prefix, required && (!isPtr || tag.required))
+				if err != nil {
+					return nil, err
+				}
+				params = append(params, embedded...)
+				continue
+			}
+			if names = exportedNames([]*ast.Ident{embeddedName(f.Type)}); len(names) == 0 && name == "" {
+				continue
+			}
+		}
+		if name != "" {
+			names = []string{name}
+		}
+		if tag.rules != nil {
+			if childR, err = childR.constrain(tag.rules); err != nil {
+				return nil, fmt.Errorf("parse tag of field in model(%s) at %s error(%v)", sm.name, sm.p.fset.Position(f.Pos()), err)
+			}
+		}
+		desc := tag.desc
+		if desc == "" {
+			desc = docDescription(f.Doc, f.Comment)
+		}
+		for _, name := range names {
+			name = nestedParamName(prefix, name)
+			if childR.kind == objectKind {
+				nested, err := e.expand(nm, name, required && tag.required)
+				if err != nil {
+					return nil, err
+				}
+				params = append(params, nested...)
+				continue
+			}
+			para := &swagger.Parameter{
+				In:          paramType[e.in],
+				Name:        name,
+				Required:    (required && tag.required) || e.in == path,
+				Description: desc,
+				Default:     tag.def,
+			}
+			if err = childR.parseParam(para); err != nil {
+				return nil, fmt.Errorf("field(%s) of model(%s) at %s error(%v)", name, sm.name, sm.p.fset.Position(f.Pos()), err)
+			}
+			params = append(params, para)
+		}
+	}
+	return params, nil
+}
+
+// paramName the name of parameter in the struct tags, returns empty if it isn't named
+func (e *paramExpander) paramName(stag reflect.StructTag) string {
+	for _, key := range append(paramTags[e.in], "json") {
+		if name := strings.Split(stag.Get(key), ",")[0]; name != "" {
+			return name
+		}
+	}
+	return ""
+}
+
+// nestedParamName the name of nested parameter by the nesting style
+func nestedParamName(prefix, name string) string {
+	switch {
+	case prefix == "":
+		return name
+	case paramNesting == bracketNesting:
+		return prefix + "[" + name + "]"
+	}
+	return prefix + "." + name
+}
+
+// structType resolve the struct type of model, returns nil if it isn't a struct
+func (m *model) structType() (*model, *ast.StructType, error) {
+	switch t := m.Expr.(type) {
+	case *ast.StarExpr:
+		return m.clone(t.X).structType()
+	case *ast.StructType:
+		return m, t, nil
+	case *ast.Ident, *ast.SelectorExpr:
+		schema := fmt.Sprint(t)
+		if sel, ok := t.(*ast.SelectorExpr); ok {
+			schema = fmt.Sprintf("%s.%s", sel.X, sel.Sel)
+		}
+		nm, err := m.p.findModelBySchema(m.filename, schema)
+		if err != nil {
+			return nil, nil, fmt.Errorf("findModelBySchema filename(%s) schema(%s) error(%v)", m.filename, schema, err)
+		}
+		return nm.structType()
+	}
+	return nil, nil, nil
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"go/ast"
 	"go/token"
+	"sort"
 
 	"github.com/teambition/swaggo/swagger"
 )
@@ -13,6 +14,7 @@ type resource struct {
 	*pkg
 	// maybe has several controllers
 	controllers map[string]*controller // ctrl name -> ctrl
+	ctrlNames   []string               // ctrl names in declaration order
 }
 
 // newResoucre an api definition
@@ -26,7 +28,14 @@ func newResoucre(importPath string, justGoPath bool) (*resource, error) {
 		pkg:         p,
 		controllers: map[string]*controller{},
 	}
-	for filename, f := range p.Files {
+	// walk the files in order to make the output stable
+	filenames := make([]string, 0, len(p.Files))
+	for filename := range p.Files {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+	for _, filename := range filenames {
+		f := p.Files[filename]
 		for _, d := range f.Decls {
 			switch specDecl := d.(type) {
 			case *ast.FuncDecl:
@@ -48,6 +57,7 @@ func newResoucre(importPath string, justGoPath bool) (*resource, error) {
 								}
 								m.ctrl = ctrl
 								r.controllers[ctrlName] = ctrl
+								r.ctrlNames = append(r.ctrlNames, ctrlName)
 							} else {
 								ctrl.methods = append(ctrl.methods, &method{
 									doc:      specDecl.Doc,
@@ -74,6 +84,7 @@ func newResoucre(importPath string, justGoPath bool) (*resource, error) {
 										filename: filename,
 										name:     t.Name.Name,
 									}
+									r.ctrlNames = append(r.ctrlNames, ctrlName)
 								} else {
 									ctrl.doc = specDecl.Doc
 								}
@@ -90,8 +101,8 @@ func newResoucre(importPath string, justGoPath bool) (*resource, error) {
 // run gernerate swagger doc
 func (r *resource) run(s *swagger.Swagger) error {
 	// parse controllers
-	for _, ctrl := range r.controllers {
-		if err := ctrl.parse(s); err != nil {
+	for _, name := range r.ctrlNames {
+		if err := r.controllers[name].parse(s); err != nil {
 			return err
 		}
 	}
@@ -1,9 +1,13 @@
 package parser
 
 import (
+	"fmt"
 	"go/ast"
+	"io/ioutil"
+	"mime"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"unicode"
@@ -23,6 +27,8 @@ func str2RealType(s string, typ string) (ret interface{}, err error) {
 	switch typ {
 	case "int", "int64", "int32", "int16", "int8":
 		ret, err = strconv.Atoi(s)
+	case "uint", "uint64", "uint32", "uint16", "uint8":
+		ret, err = strconv.ParseUint(s, 10, 64)
 	case "bool":
 		ret, err = strconv.ParseBool(s)
 	case "float64":
@@ -73,7 +79,62 @@ func tagTrimPrefixAndSpace(s *string, prefix string) bool {
 // isDocComments check if comments has `@` prefix
 func isDocComments(comments *ast.CommentGroup) bool {
 	for _, c := range strings.Split(comments.Text(), "\n") {
-		if strings.HasPrefix(c, docPrefix) {
+		if strings.HasPrefix(c, docPrefix) && !isModelTag(c) {
+			return true
+		}
+	}
+	return false
+}
+
+// docDescription the description in the first non-empty comments, the annotations are excluded
+func docDescription(comments ...*ast.CommentGroup) string {
+	for _, c := range comments {
+		if c == nil {
+			continue
+		}
+		lines := []string{}
+		for _, line := range strings.Split(c.Text(), "\n") {
+			if !strings.HasPrefix(strings.TrimSpace(line), docPrefix) {
+				lines = append(lines, line)
+			}
+		}
+		if desc := strings.TrimSpace(strings.Join(lines, "\n")); desc != "" {
+			return desc
+		}
+	}
+	return ""
+}
+
+// docSummary the first sentence of the first paragraph of description
+func docSummary(desc string) string {
+	para := strings.Join(strings.Fields(strings.SplitN(desc, "\n\n", 2)[0]), " ")
+	if idx := strings.Index(para, ". "); idx != -1 {
+		return para[:idx+1]
+	}
+	return para
+}
+
+// descriptionFile load the description from the file like `file(docs/overview.md)`,
+// the file is relative to the annotated source file, returns s if it isn't a file
+func descriptionFile(filename, s string) (string, error) {
+	if !strings.HasPrefix(s, descFilePrefix) || !strings.HasSuffix(s, ")") {
+		return s, nil
+	}
+	file := strings.TrimSpace(s[len(descFilePrefix) : len(s)-1])
+	if !filepath.IsAbs(file) {
+		file = filepath.Join(filepath.Dir(filename), file)
+	}
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return "", fmt.Errorf("load description(%s) in file(%s) error(%v)", s, filename, err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// isModelTag check if the annotation is for models, like `@name`
+func isModelTag(c string) bool {
+	for _, tag := range []string{modelName, modelType} {
+		if strings.HasPrefix(c, tag) {
 			return true
 		}
 	}
@@ -116,14 +177,59 @@ func getparams(str string) []string {
 	return r
 }
 
-// contentTypeByDoc Get content types from comment
-func contentTypeByDoc(s string) []string {
+// contentTypeByDoc Get content types from comment,
+// the element is a builtin alias like `json`, a custom alias of config or a full media type like `text/csv`
+func contentTypeByDoc(s string) ([]string, error) {
 	result := []string{}
 	tmp := strings.Split(s, ",")
 	for _, v := range tmp {
-		result = append(result, contentType[v])
+		v = strings.TrimSpace(v)
+		if t, ok := contentType[v]; ok {
+			result = append(result, t)
+		} else if t, ok := contentTypeAliases[v]; ok {
+			result = append(result, t)
+		} else if strings.Contains(v, "/") {
+			if err := checkMediaType(v); err != nil {
+				return nil, err
+			}
+			result = append(result, v)
+		} else {
+			return nil, fmt.Errorf("unknown content type(%s), should be a media type like `text/csv` or an alias in (%s)", v, strings.Join(contentTypeNames(), ", "))
+		}
+	}
+	return result, nil
+}
+
+// isMediaTypes check if the string is the full media types like `text/csv,application/json`
+func isMediaTypes(s string) bool {
+	for _, v := range strings.Split(s, ",") {
+		if !strings.Contains(v, "/") || checkMediaType(v) != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// checkMediaType check the media type like `application/problem+json` or `text/plain; charset=utf-8`
+func checkMediaType(s string) error {
+	t, _, err := mime.ParseMediaType(s)
+	if err != nil || strings.Count(t, "/") != 1 || strings.HasPrefix(t, "/") || strings.HasSuffix(t, "/") {
+		return fmt.Errorf("invalid media type(%s)", s)
+	}
+	return nil
+}
+
+// contentTypeNames the sorted aliases of content types
+func contentTypeNames() []string {
+	names := []string{}
+	for name := range contentType {
+		names = append(names, name)
+	}
+	for name := range contentTypeAliases {
+		names = append(names, name)
 	}
-	return result
+	sort.Strings(names)
+	return names
 }
 
 // subset returns true if the first array is completely
@@ -0,0 +1,274 @@
+package parser
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// fieldRules the constraints of struct field described by the tags of validator libraries and swaggo,
+// the bounds are the values of numbers or the lengths of strings and arrays
+type fieldRules struct {
+	required     bool
+	min, max     *float64
+	exclusiveMin bool
+	exclusiveMax bool
+	multipleOf   *float64
+	unique       bool
+	enum         []string
+	equal        string // the value of scalars or the length of arrays and maps, like `eq=admin`
+	pattern      string
+	format       string
+	items        *fieldRules // the rules of elements
+}
+
+// validatorTags the parsers of struct tags of validator libraries,
+// other libraries can be supported by registering the parsers of their tags
+var validatorTags = map[string]func(tag string, rules *fieldRules) error{
+	// github.com/go-playground/validator
+	"validate": parsePlaygroundRules,
+	// github.com/gin-gonic/gin, based on go-playground/validator
+	"binding": parsePlaygroundRules,
+}
+
+// parseValidatorTags parse the tags of validator libraries in order of tag names,
+// returns nil if the field has none of them
+func parseValidatorTags(stag reflect.StructTag) (*fieldRules, error) {
+	names := make([]string, 0, len(validatorTags))
+	for name := range validatorTags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var rules *fieldRules
+	for _, name := range names {
+		tag, ok := stag.Lookup(name)
+		if !ok {
+			continue
+		}
+		if rules == nil {
+			rules = &fieldRules{}
+		}
+		if err := validatorTags[name](tag, rules); err != nil {
+			return nil, fmt.Errorf("tag(%s:%q) error(%v)", name, tag, err)
+		}
+	}
+	return rules, nil
+}
+
+// playgroundFormats the swagger formats of go-playground/validator rules
+var playgroundFormats = map[string]string{
+	"email":            "email",
+	"url":              "uri",
+	"uri":              "uri",
+	"uuid":             "uuid",
+	"uuid3":            "uuid",
+	"uuid4":            "uuid",
+	"uuid5":            "uuid",
+	"ipv4":             "ipv4",
+	"ipv6":             "ipv6",
+	"hostname":         "hostname",
+	"hostname_rfc1123": "hostname",
+	"base64":           "byte",
+}
+
+// playgroundPatterns the patterns of go-playground/validator rules
+var playgroundPatterns = map[string]string{
+	"alpha":       "^[a-zA-Z]+$",
+	"alphanum":    "^[a-zA-Z0-9]+$",
+	"numeric":     "^[-+]?[0-9]+(?:\\.[0-9]+)?$",
+	"number":      "^[0-9]+$",
+	"hexadecimal": "^(0[xX])?[0-9a-fA-F]+$",
+	"hexcolor":    "^#(?:[0-9a-fA-F]{3}|[0-9a-fA-F]{6})$",
+	"e164":        "^\\+[1-9]?[0-9]{7,14}$",
+}
+
+// parsePlaygroundRules parse the rules of go-playground/validator like `required,min=1,max=100,oneof=a b,email`,
+// the rules after `dive` are the rules of elements, the unknown rules are ignored
+func parsePlaygroundRules(tag string, rules *fieldRules) error {
+	keys := false
+	for _, rule := range strings.Split(tag, ",") {
+		rule = strings.TrimSpace(rule)
+		name, param := rule, ""
+		if idx := strings.Index(rule, "="); idx != -1 {
+			name, param = rule[:idx], rule[idx+1:]
+		}
+		switch {
+		case name == "keys":
+			keys = true
+			continue
+		case name == "endkeys":
+			keys = false
+			continue
+		case keys:
+			// the rules of map keys can't be described
+			continue
+		case strings.Contains(rule, "|"):
+			// the alternative rules can't be described
+			continue
+		case playgroundBounds[name] && !isNumber(param):
+			// the bounds of durations or times like `min=1h` and `gt` can't be described
+			continue
+		}
+
+		var err error
+		switch name {
+		case "dive":
+			rules.items = &fieldRules{}
+			rules = rules.items
+		case "required":
+			rules.required = true
+		case "min", "gte":
+			rules.min, err = ruleBound(rule, param)
+			rules.exclusiveMin = false
+		case "gt":
+			rules.min, err = ruleBound(rule, param)
+			rules.exclusiveMin = true
+		case "max", "lte":
+			rules.max, err = ruleBound(rule, param)
+			rules.exclusiveMax = false
+		case "lt":
+			rules.max, err = ruleBound(rule, param)
+			rules.exclusiveMax = true
+		case "len":
+			if rules.min, err = ruleBound(rule, param); err == nil {
+				rules.max = rules.min
+				rules.exclusiveMin, rules.exclusiveMax = false, false
+			}
+		case "eq":
+			rules.equal = param
+		case "oneof":
+			rules.enum = oneofValues(param)
+		case "unique":
+			rules.unique = true
+		default:
+			if format, ok := playgroundFormats[name]; ok {
+				rules.format = format
+			} else if pattern, ok := playgroundPatterns[name]; ok {
+				rules.pattern = pattern
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// playgroundBounds the go-playground/validator rules whose params are the bounds of values or lengths
+var playgroundBounds = map[string]bool{
+	"min": true, "max": true, "gt": true, "gte": true, "lt": true, "lte": true, "len": true,
+}
+
+// isNumber check if the param of rule is a number
+func isNumber(param string) bool {
+	_, err := strconv.ParseFloat(param, 64)
+	return err == nil
+}
+
+// ruleBound parse the number param of rule
+func ruleBound(rule, param string) (*float64, error) {
+	f, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return nil, fmt.Errorf("rule(%s) need a number", rule)
+	}
+	return &f, nil
+}
+
+// oneofValues split the values of `oneof` by spaces, the values with spaces are quoted by `'`
+func oneofValues(param string) []string {
+	values := []string{}
+	for param = strings.TrimSpace(param); param != ""; param = strings.TrimSpace(param) {
+		if param[0] == '\'' {
+			if end := strings.Index(param[1:], "'"); end != -1 {
+				values = append(values, param[1:end+1])
+				param = param[end+2:]
+				continue
+			}
+		}
+		end := strings.Index(param, " ")
+		if end == -1 {
+			end = len(param)
+		}
+		values = append(values, param[:end])
+		param = param[end:]
+	}
+	return values
+}
+
+// constrain deep copy the result with the validation keywords of rules by its kind
+func (r *result) constrain(rules *fieldRules) (*result, error) {
+	q := r.clone()
+	v := &q.validation
+	switch r.kind {
+	case innerKind:
+		switch r.sType {
+		case "string":
+			v.MinLength, v.MaxLength = lengthBound(rules.min, rules.exclusiveMin, 1), lengthBound(rules.max, rules.exclusiveMax, -1)
+			if rules.pattern != "" {
+				v.Pattern = rules.pattern
+			}
+		case "integer", "number":
+			if rules.min != nil {
+				v.Minimum, v.ExclusiveMinimum = rules.min, rules.exclusiveMin
+			}
+			if rules.max != nil {
+				v.Maximum, v.ExclusiveMaximum = rules.max, rules.exclusiveMax
+			}
+			v.MultipleOf = rules.multipleOf
+		}
+		if rules.format != "" {
+			q.sFormat = rules.format
+		}
+		enum := rules.enum
+		if len(enum) == 0 && rules.equal != "" {
+			// the scalar equals the value
+			enum = []string{rules.equal}
+		}
+		if len(enum) != 0 {
+			v.Enum = []interface{}{}
+			for _, s := range enum {
+				e, err := str2RealType(s, r.buildin)
+				if err != nil {
+					return nil, fmt.Errorf("enum(%s) of type(%s) error(%v)", s, r.buildin, err)
+				}
+				v.Enum = append(v.Enum, e)
+			}
+		}
+	case arrayKind, mapKind:
+		if r.kind == arrayKind {
+			v.MinItems, v.MaxItems = lengthBound(rules.min, rules.exclusiveMin, 1), lengthBound(rules.max, rules.exclusiveMax, -1)
+			if rules.equal != "" && isNumber(rules.equal) {
+				// the length of array equals the value
+				n, _ := strconv.ParseFloat(rules.equal, 64)
+				v.MinItems, v.MaxItems = lengthBound(&n, false, 0), lengthBound(&n, false, 0)
+			}
+			v.UniqueItems = rules.unique
+		}
+		if len(rules.enum) != 0 && rules.items == nil {
+			// the enum of array describes its items
+			rules.items = &fieldRules{enum: rules.enum}
+		}
+		if rules.items != nil && r.item != nil {
+			item, err := r.item.constrain(rules.items)
+			if err != nil {
+				return nil, err
+			}
+			q.item = item
+		}
+	}
+	return q, nil
+}
+
+// lengthBound the integer bound of length, the exclusive bound is moved by delta
+func lengthBound(bound *float64, exclusive bool, delta int64) *int64 {
+	if bound == nil {
+		return nil
+	}
+	n := int64(*bound)
+	if exclusive {
+		n += delta
+	}
+	return &n
+}
@@ -0,0 +1,369 @@
+package swagger
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+// the kinds of objects which decide where the external references are bundled
+const (
+	documentKind  = "document"
+	pathItemKind  = "pathItem"
+	operationKind = "operation"
+	parameterKind = "parameter"
+	responseKind  = "response"
+	schemaKind    = "schema"
+	otherKind     = "other"
+	// the objects or arrays of kinds
+	pathItemsKind  = "pathItems"
+	parametersKind = "parameters"
+	responsesKind  = "responses"
+	schemasKind    = "schemas"
+)
+
+// elementKinds the kinds of elements in the objects or arrays
+var elementKinds = map[string]string{
+	pathItemsKind:  pathItemKind,
+	parametersKind: parameterKind,
+	responsesKind:  responseKind,
+	schemasKind:    schemaKind,
+}
+
+// kindSections the sections where the external objects of kinds are bundled,
+// the objects of the other kinds are inlined
+var kindSections = map[string]string{
+	schemaKind:    "definitions",
+	parameterKind: "parameters",
+	responseKind:  "responses",
+}
+
+// ExternalizeDefinitions move the definitions of doc into separate files in dir,
+// the references to them are replaced with the relative paths of files,
+// returns the files: filename(joined with dir) -> definition
+func ExternalizeDefinitions(doc map[string]interface{}, dir, ext string) (map[string]interface{}, error) {
+	definitions, _ := doc["definitions"].(map[string]interface{})
+	filenames := map[string]string{}
+	names := map[string]string{}
+	for _, name := range sortedKeys(definitions) {
+		filename := FileSafeName(name) + ext
+		if other, ok := names[filename]; ok {
+			return nil, fmt.Errorf("definitions(%s) and (%s) are written to the same file(%s)", other, name, filename)
+		}
+		names[filename] = name
+		filenames[name] = filename
+	}
+	rewrite := func(prefix string) func(ref string) string {
+		return func(ref string) string {
+			if !strings.HasPrefix(ref, definitionsPrefix) {
+				return ref
+			}
+			if filename, ok := filenames[unescapePointer(strings.TrimPrefix(ref, definitionsPrefix))]; ok {
+				return path.Join(prefix, filename)
+			}
+			return ref
+		}
+	}
+
+	files := map[string]interface{}{}
+	for name, filename := range filenames {
+		files[path.Join(dir, filename)] = RewriteRefs(definitions[name], rewrite(""))
+	}
+	delete(doc, "definitions")
+	for k, v := range doc {
+		doc[k] = RewriteRefs(v, rewrite(dir))
+	}
+	return files, nil
+}
+
+// FileSafeName replace the characters which are unsafe in filename with `_`
+func FileSafeName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '-' || r == '.' || r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+// RewriteRefs copy the value and rewrite the `$ref`s in it
+func RewriteRefs(v interface{}, fn func(ref string) string) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(t))
+		for k, v := range t {
+			m[k] = RewriteRefs(v, fn)
+		}
+		if ref, ok := t["$ref"].(string); ok {
+			m["$ref"] = fn(ref)
+		}
+		return m
+	case []interface{}:
+		l := make([]interface{}, len(t))
+		for i, v := range t {
+			l[i] = RewriteRefs(v, fn)
+		}
+		return l
+	}
+	return v
+}
+
+// bundler inline the external references of local files
+type bundler struct {
+	filename string                       // the absolute filename of root doc
+	root     map[string]interface{}       // the bundled doc
+	docs     map[string]interface{}       // absolute filename -> doc
+	names    map[string]map[string]string // section -> source -> name
+	sources  map[string]map[string]string // section -> name -> source
+	inlining map[string]bool              // the sources which are being inlined
+}
+
+// Bundle read the swagger file and inline the external references of local files
+// into a self-contained doc, the external schemas, parameters and responses are
+// moved to the definitions, parameters and responses of doc, the others are inlined
+func Bundle(filename string) (interface{}, error) {
+	doc, err := ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return BundleDocument(doc, filename)
+}
+
+// BundleDocument inline the external references of local files like Bundle,
+// the relative references are resolved by the filename of doc
+func BundleDocument(doc interface{}, filename string) (interface{}, error) {
+	abs, err := filepath.Abs(filename)
+	if err != nil {
+		return nil, err
+	}
+	root, ok := doc.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("swagger file(%s) should be an object", filename)
+	}
+	b := &bundler{
+		filename: abs,
+		root:     map[string]interface{}{},
+		docs:     map[string]interface{}{abs: root},
+		names:    map[string]map[string]string{},
+		sources:  map[string]map[string]string{},
+		inlining: map[string]bool{},
+	}
+	for _, section := range kindSections {
+		b.names[section] = map[string]string{}
+		b.sources[section] = map[string]string{}
+		objs, _ := root[section].(map[string]interface{})
+		for name := range objs {
+			b.sources[section][name] = abs + "#/" + section + "/" + escapePointer(name)
+		}
+	}
+	for k, v := range root {
+		if v, err = b.walk(v, childKind(documentKind, k), abs); err != nil {
+			return nil, err
+		}
+		// the walked sections may be extended by the bundled objects
+		objs, ok1 := b.root[k].(map[string]interface{})
+		walked, ok2 := v.(map[string]interface{})
+		if ok1 && ok2 {
+			for name, obj := range walked {
+				objs[name] = obj
+			}
+			continue
+		}
+		b.root[k] = v
+	}
+	return b.root, nil
+}
+
+// childKind the kind of child value by the key
+func childKind(kind, key string) string {
+	if kind, ok := elementKinds[kind]; ok {
+		return kind
+	}
+	switch kind {
+	case documentKind:
+		switch key {
+		case "paths":
+			return pathItemsKind
+		case "definitions":
+			return schemasKind
+		case "parameters":
+			return parametersKind
+		case "responses":
+			return responsesKind
+		}
+	case pathItemKind:
+		if key == "parameters" {
+			return parametersKind
+		}
+		for _, method := range Methods {
+			if key == method {
+				return operationKind
+			}
+		}
+	case operationKind:
+		switch key {
+		case "parameters":
+			return parametersKind
+		case "responses":
+			return responsesKind
+		}
+	case parameterKind, responseKind:
+		if key == "schema" {
+			return schemaKind
+		}
+	case schemaKind:
+		switch key {
+		case "items", "allOf", "additionalProperties":
+			return schemaKind
+		case "properties":
+			return schemasKind
+		}
+	}
+	return otherKind
+}
+
+// walk copy the value of kind and bundle the external references in it
+func (b *bundler) walk(v interface{}, kind, filename string) (interface{}, error) {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		if ref, ok := t["$ref"].(string); ok {
+			return b.ref(t, ref, kind, filename)
+		}
+		m := make(map[string]interface{}, len(t))
+		for k, v := range t {
+			var err error
+			if m[k], err = b.walk(v, childKind(kind, k), filename); err != nil {
+				return nil, err
+			}
+		}
+		return m, nil
+	case []interface{}:
+		elementKind, ok := elementKinds[kind]
+		if !ok {
+			// like `allOf` or `items` of schema
+			elementKind = kind
+		}
+		l := make([]interface{}, len(t))
+		for i, v := range t {
+			var err error
+			if l[i], err = b.walk(v, elementKind, filename); err != nil {
+				return nil, err
+			}
+		}
+		return l, nil
+	}
+	return v, nil
+}
+
+// ref bundle the reference of kind in the file
+func (b *bundler) ref(obj map[string]interface{}, ref, kind, filename string) (interface{}, error) {
+	file, pointer := ref, ""
+	if idx := strings.Index(ref, "#"); idx != -1 {
+		file, pointer = ref[:idx], ref[idx+1:]
+	}
+	switch {
+	case strings.Contains(file, "://"):
+		// the remote references are not bundled
+		return copyValue(obj), nil
+	case file == "":
+		file = filename
+	case !filepath.IsAbs(file):
+		file = filepath.Join(filepath.Dir(filename), filepath.FromSlash(file))
+	}
+	if file == b.filename {
+		r := copyValue(obj).(map[string]interface{})
+		r["$ref"] = "#" + pointer
+		return r, nil
+	}
+
+	source := file + "#" + pointer
+	target, err := b.lookup(file, pointer)
+	if err != nil {
+		return nil, err
+	}
+	section, ok := kindSections[kind]
+	if !ok {
+		if b.inlining[source] {
+			return nil, fmt.Errorf("circular reference(%s) can't be inlined", source)
+		}
+		b.inlining[source] = true
+		defer delete(b.inlining, source)
+		return b.walk(target, kind, file)
+	}
+
+	name, ok := b.names[section][source]
+	if !ok {
+		name = refName(file, pointer)
+		if other, ok := b.sources[section][name]; ok {
+			return nil, fmt.Errorf("reference(%s) and (%s) are bundled to the same name(#/%s/%s)", source, other, section, name)
+		}
+		b.names[section][source] = name
+		b.sources[section][name] = source
+		// the name is reserved before walking for the circular references
+		bundled, err := b.walk(target, kind, file)
+		if err != nil {
+			return nil, err
+		}
+		objs, ok := b.root[section].(map[string]interface{})
+		if !ok {
+			objs = map[string]interface{}{}
+			b.root[section] = objs
+		}
+		objs[name] = bundled
+	}
+	// keep the siblings of `$ref` like the generated `type`
+	r := copyValue(obj).(map[string]interface{})
+	r["$ref"] = "#/" + section + "/" + escapePointer(name)
+	return r, nil
+}
+
+// lookup find the value by the JSON pointer in file
+func (b *bundler) lookup(filename, pointer string) (interface{}, error) {
+	doc, ok := b.docs[filename]
+	if !ok {
+		var err error
+		if doc, err = ReadFile(filename); err != nil {
+			return nil, err
+		}
+		b.docs[filename] = doc
+	}
+	v, ok := lookupPointer(doc, pointer)
+	if !ok {
+		return nil, fmt.Errorf("unresolvable reference(%s#%s)", filename, pointer)
+	}
+	return v, nil
+}
+
+// ReadRef read the value referenced by `file#/pointer` from local file
+func ReadRef(ref string) (interface{}, error) {
+	file, pointer := ref, ""
+	if idx := strings.Index(ref, "#"); idx != -1 {
+		file, pointer = ref[:idx], ref[idx+1:]
+	}
+	doc, err := ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	v, ok := lookupPointer(doc, pointer)
+	if !ok {
+		return nil, fmt.Errorf("unresolvable reference(%s)", ref)
+	}
+	return v, nil
+}
+
+// IsExternalRef check if the reference points to other file
+func IsExternalRef(ref string) bool {
+	return ref != "" && !strings.HasPrefix(ref, "#")
+}
+
+// refName the name of bundled object, the last token of pointer
+// or the filename without extension
+func refName(filename, pointer string) string {
+	if idx := strings.LastIndex(pointer, "/"); idx != -1 && idx != len(pointer)-1 {
+		return unescapePointer(pointer[idx+1:])
+	}
+	base := filepath.Base(filename)
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
@@ -0,0 +1,79 @@
+package swagger
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBundle(t *testing.T) {
+	assert := assert.New(t)
+
+	doc, err := Unmarshal([]byte(`
+swagger: "2.0"
+info: {title: test, version: "1.0.0"}
+paths:
+  /users:
+    get:
+      responses:
+        200: {description: OK, schema: {type: array, items: {$ref: "#/definitions/User"}}}
+definitions:
+  User:
+    properties:
+      team: {$ref: "#/definitions/Team"}
+  Team:
+    properties:
+      owner: {$ref: "#/definitions/User"}
+`))
+	assert.Nil(err)
+	origin := copyValue(doc)
+
+	definitions, err := ExternalizeDefinitions(doc.(map[string]interface{}), "definitions", ".json")
+	assert.Nil(err)
+	assert.Nil(doc.(map[string]interface{})["definitions"])
+	assert.Equal(2, len(definitions))
+	assert.Equal("Team.json", definitions["definitions/User.json"].(map[string]interface{})["properties"].(map[string]interface{})["team"].(map[string]interface{})["$ref"])
+
+	dir, err := ioutil.TempDir("", "swaggo")
+	assert.Nil(err)
+	defer os.RemoveAll(dir)
+	assert.Nil(os.Mkdir(filepath.Join(dir, "definitions"), 0755))
+	definitions["swagger.json"] = doc
+	for filename, v := range definitions {
+		data, err := json.Marshal(v)
+		assert.Nil(err)
+		assert.Nil(ioutil.WriteFile(filepath.Join(dir, filename), data, 0644))
+	}
+	bundled, err := Bundle(filepath.Join(dir, "swagger.json"))
+	assert.Nil(err)
+	assert.Equal(origin, bundled)
+
+	// the other objects are inlined
+	assert.Nil(ioutil.WriteFile(filepath.Join(dir, "paths.yaml"), []byte(`
+users:
+  get:
+    parameters:
+    - $ref: "#/limit"
+    responses:
+      200: {description: OK}
+limit: {name: limit, in: query, type: integer}
+`), 0644))
+	assert.Nil(ioutil.WriteFile(filepath.Join(dir, "swagger.yaml"), []byte(`
+swagger: "2.0"
+info: {title: test, version: "1.0.0"}
+paths:
+  /users: {$ref: "paths.yaml#/users"}
+`), 0644))
+	bundled, err = Bundle(filepath.Join(dir, "swagger.yaml"))
+	assert.Nil(err)
+	assert.Empty(Validate(bundled))
+	assert.NotNil(bundled.(map[string]interface{})["paths"].(map[string]interface{})["/users"].(map[string]interface{})["get"])
+	assert.Equal(map[string]interface{}{"limit": map[string]interface{}{"name": "limit", "in": "query", "type": "integer"}}, bundled.(map[string]interface{})["parameters"])
+
+	_, err = Bundle(filepath.Join(dir, "none.yaml"))
+	assert.NotNil(err)
+}
@@ -0,0 +1,127 @@
+package swagger
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// untagged the group of operations and models without tags
+const untagged = "Others"
+
+type changelogGroup struct {
+	added, removed []*Change
+	changed        [][]*Change // changes of each operation
+	models         []*Change
+}
+
+// Changelog render the changes between two documents as markdown,
+// the operations and models are grouped by tags
+func Changelog(title string, from, to interface{}) string {
+	fromDoc, _ := from.(map[string]interface{})
+	toDoc, _ := to.(map[string]interface{})
+	modelTags := definitionTags(fromDoc)
+	for name, tags := range definitionTags(toDoc) {
+		modelTags[name] = tags
+	}
+
+	groups := map[string]*changelogGroup{}
+	group := func(tags []string) (r []*changelogGroup) {
+		if len(tags) == 0 {
+			tags = []string{untagged}
+		}
+		for _, tag := range tags {
+			if groups[tag] == nil {
+				groups[tag] = &changelogGroup{}
+			}
+			r = append(r, groups[tag])
+		}
+		return
+	}
+
+	// the changes of one operation are continuous
+	var last *Change
+	for _, c := range Diff(from, to) {
+		if c.Definition != "" {
+			for _, g := range group(modelTags[c.Definition]) {
+				g.models = append(g.models, c)
+			}
+			continue
+		}
+		isOperation := c.Location == (&Difference{Path: []string{"paths", c.Path, c.Method}}).Pointer()
+		for _, g := range group(c.Tags) {
+			switch {
+			case isOperation && c.Kind == Added:
+				g.added = append(g.added, c)
+			case isOperation && c.Kind == Removed:
+				g.removed = append(g.removed, c)
+			case last != nil && last.Path == c.Path && last.Method == c.Method && len(g.changed) != 0:
+				g.changed[len(g.changed)-1] = append(g.changed[len(g.changed)-1], c)
+			default:
+				g.changed = append(g.changed, []*Change{c})
+			}
+		}
+		last = c
+	}
+
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "# %s\n", title)
+	if len(groups) == 0 {
+		fmt.Fprint(buf, "\nNo API changes.\n")
+		return buf.String()
+	}
+	tags := []string{}
+	for tag := range groups {
+		if tag != untagged {
+			tags = append(tags, tag)
+		}
+	}
+	sort.Strings(tags)
+	if groups[untagged] != nil {
+		tags = append(tags, untagged)
+	}
+	for _, tag := range tags {
+		g := groups[tag]
+		fmt.Fprintf(buf, "\n## %s\n", tag)
+		if len(g.added) != 0 {
+			fmt.Fprint(buf, "\n### Added\n\n")
+			for _, c := range g.added {
+				fmt.Fprintf(buf, "- %s\n", operationName(c))
+			}
+		}
+		if len(g.removed) != 0 {
+			fmt.Fprint(buf, "\n### Removed\n\n")
+			for _, c := range g.removed {
+				fmt.Fprintf(buf, "- %s%s\n", operationName(c), breakingMark(c))
+			}
+		}
+		if len(g.changed) != 0 {
+			fmt.Fprint(buf, "\n### Changed\n\n")
+			for _, cs := range g.changed {
+				fmt.Fprintf(buf, "- %s\n", operationName(cs[0]))
+				for _, c := range cs {
+					fmt.Fprintf(buf, "  - %s%s\n", c.Message, breakingMark(c))
+				}
+			}
+		}
+		if len(g.models) != 0 {
+			fmt.Fprint(buf, "\n### Models\n\n")
+			for _, c := range g.models {
+				fmt.Fprintf(buf, "- %s `%s`\n", strings.ToUpper(c.Kind[:1])+c.Kind[1:], c.Definition)
+			}
+		}
+	}
+	return buf.String()
+}
+
+func operationName(c *Change) string {
+	return fmt.Sprintf("`%s %s`", strings.ToUpper(c.Method), c.Path)
+}
+
+func breakingMark(c *Change) string {
+	if c.Breaking {
+		return " **(breaking)**"
+	}
+	return ""
+}
@@ -0,0 +1,124 @@
+package swagger
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Difference a changed value between two swagger documents
+type Difference struct {
+	Path []string    // keys from the root of document
+	Old  interface{} // nil means added
+	New  interface{} // nil means removed
+}
+
+// Pointer the JSON pointer of the changed value
+func (d *Difference) Pointer() string {
+	tokens := make([]string, len(d.Path))
+	for i, p := range d.Path {
+		tokens[i] = escapePointer(p)
+	}
+	return "#/" + strings.Join(tokens, "/")
+}
+
+func (d *Difference) String() string {
+	switch {
+	case d.Old == nil:
+		return fmt.Sprintf("+ %s: %s", d.Pointer(), jsonString(d.New))
+	case d.New == nil:
+		return fmt.Sprintf("- %s: %s", d.Pointer(), jsonString(d.Old))
+	}
+	return fmt.Sprintf("~ %s: %s => %s", d.Pointer(), jsonString(d.Old), jsonString(d.New))
+}
+
+// Compare find the differences between two documents decoded by Unmarshal
+// the order of object keys is ignored
+func Compare(from, to interface{}) []*Difference {
+	return compare(nil, from, to)
+}
+
+func compare(path []string, from, to interface{}) (diffs []*Difference) {
+	child := func(key string) []string {
+		p := make([]string, len(path), len(path)+1)
+		copy(p, path)
+		return append(p, key)
+	}
+	switch o := from.(type) {
+	case map[string]interface{}:
+		n, ok := to.(map[string]interface{})
+		if !ok {
+			break
+		}
+		for _, k := range sortedKeys(o) {
+			if nv, ok := n[k]; ok {
+				diffs = append(diffs, compare(child(k), o[k], nv)...)
+			} else {
+				diffs = append(diffs, &Difference{Path: child(k), Old: o[k]})
+			}
+		}
+		for _, k := range sortedKeys(n) {
+			if _, ok := o[k]; !ok {
+				diffs = append(diffs, &Difference{Path: child(k), New: n[k]})
+			}
+		}
+		return
+	case []interface{}:
+		n, ok := to.([]interface{})
+		if !ok || len(o) != len(n) {
+			break
+		}
+		for i := range o {
+			diffs = append(diffs, compare(child(fmt.Sprint(i)), o[i], n[i])...)
+		}
+		return
+	}
+	if !reflect.DeepEqual(from, to) {
+		diffs = append(diffs, &Difference{Path: path, Old: from, New: to})
+	}
+	return
+}
+
+// FormatDifferences render the differences grouped by
+// the top-level sections and their entries, like:
+//
+//	paths:
+//	  /users/{id}:
+//	    ~ get/summary: "old" => "new"
+//	definitions:
+//	  + User: {...}
+func FormatDifferences(diffs []*Difference) string {
+	buf := &bytes.Buffer{}
+	section, entry := "", ""
+	for _, d := range diffs {
+		rest := d.Path
+		if len(rest) > 0 && rest[0] != section {
+			section, entry = rest[0], ""
+			fmt.Fprintf(buf, "%s:\n", section)
+		}
+		if len(rest) > 0 {
+			rest = rest[1:]
+		}
+		indent := "  "
+		// paths and definitions are grouped by their entries
+		if (section == "paths" || section == "definitions") && len(rest) > 1 {
+			if rest[0] != entry {
+				entry = rest[0]
+				fmt.Fprintf(buf, "  %s:\n", entry)
+			}
+			rest = rest[1:]
+			indent = "    "
+		}
+		key := strings.Join(rest, "/")
+		switch {
+		case d.Old == nil:
+			fmt.Fprintf(buf, "%s+ %s: %s\n", indent, key, jsonString(d.New))
+		case d.New == nil:
+			fmt.Fprintf(buf, "%s- %s: %s\n", indent, key, jsonString(d.Old))
+		default:
+			fmt.Fprintf(buf, "%s~ %s: %s => %s\n", indent, key, jsonString(d.Old), jsonString(d.New))
+		}
+	}
+	return buf.String()
+}
@@ -0,0 +1,67 @@
+package swagger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompare(t *testing.T) {
+	assert := assert.New(t)
+
+	from, err := Unmarshal([]byte(`{"paths": {"/users": {"get": {"summary": "list"}}}, "definitions": {"User": {"type": "object"}}}`))
+	assert.Nil(err)
+	to, err := Unmarshal([]byte(`
+definitions:
+  Team: {type: object}
+paths:
+  /users:
+    get: {summary: list users}
+`))
+	assert.Nil(err)
+	assert.Empty(Compare(from, from))
+
+	diffs := Compare(from, to)
+	assert.Equal(3, len(diffs))
+	assert.Equal(`- #/definitions/User: {"type":"object"}`, diffs[0].String())
+	assert.Equal(`+ #/definitions/Team: {"type":"object"}`, diffs[1].String())
+	assert.Equal(`~ #/paths/~1users/get/summary: "list" => "list users"`, diffs[2].String())
+	assert.Equal(`definitions:
+  - User: {"type":"object"}
+  + Team: {"type":"object"}
+paths:
+  /users:
+    ~ get/summary: "list" => "list users"
+`, FormatDifferences(diffs))
+}
+
+func TestPruneDefinitions(t *testing.T) {
+	assert := assert.New(t)
+
+	s := NewV2()
+	s.Paths = map[string]*Item{
+		"/users": &Item{
+			Get: &Operation{Responses: map[string]*Response{
+				"200": &Response{Schema: &Schema{Type: "array", Items: &Schema{Ref: "#/definitions/User"}}},
+			}},
+			Post: &Operation{Parameters: []*Parameter{
+				&Parameter{In: "body", Schema: &Schema{Ref: "#/definitions/UserForm"}},
+			}},
+		},
+	}
+	s.Definitions = map[string]*Schema{
+		"User": &Schema{Properties: map[string]*Propertie{
+			"team": &Propertie{Ref: "#/definitions/Team"},
+		}},
+		"Team": &Schema{Properties: map[string]*Propertie{
+			"owner": &Propertie{Ref: "#/definitions/User"},
+		}},
+		"UserForm": &Schema{},
+		"Private":  &Schema{Properties: map[string]*Propertie{"secret": &Propertie{Ref: "#/definitions/Secret"}}},
+		"Secret":   &Schema{},
+	}
+	assert.Equal([]string{"Private", "Secret"}, s.PruneDefinitions())
+	assert.Equal(3, len(s.Definitions))
+	assert.NotNil(s.Definitions["Team"])
+	assert.Empty(s.PruneDefinitions())
+}
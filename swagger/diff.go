@@ -0,0 +1,398 @@
+package swagger
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// change kinds
+const (
+	Added   = "added"
+	Removed = "removed"
+	Changed = "changed"
+)
+
+// Change a classified difference between two versions of swagger document
+type Change struct {
+	Breaking   bool     `json:"breaking"`
+	Kind       string   `json:"kind"` // added, removed or changed
+	Method     string   `json:"method,omitempty"`
+	Path       string   `json:"path,omitempty"`       // the api path
+	Definition string   `json:"definition,omitempty"` // the definition name
+	Tags       []string `json:"tags,omitempty"`
+	Location   string   `json:"location"` // JSON pointer of the changed value
+	Message    string   `json:"message"`
+}
+
+func (c *Change) String() string {
+	level := "non-breaking"
+	if c.Breaking {
+		level = "breaking"
+	}
+	subject := c.Definition
+	if c.Path != "" {
+		subject = strings.ToUpper(c.Method) + " " + c.Path
+	}
+	return fmt.Sprintf("[%s] %s: %s", level, subject, c.Message)
+}
+
+// HasBreaking check if there is any breaking change
+func HasBreaking(changes []*Change) bool {
+	for _, c := range changes {
+		if c.Breaking {
+			return true
+		}
+	}
+	return false
+}
+
+// Diff classify the changes between two documents decoded by Unmarshal,
+// the breaking changes are:
+//   - removed operations, responses and representations of responses
+//   - new required params and required request fields
+//   - narrowed enums of params and request fields
+//   - changed types of params and fields
+//   - removed response fields
+func Diff(from, to interface{}) []*Change {
+	d := &differ{}
+	d.from, _ = from.(map[string]interface{})
+	d.to, _ = to.(map[string]interface{})
+	d.operations()
+	d.definitions()
+	return d.changes
+}
+
+type differ struct {
+	from, to map[string]interface{}
+	changes  []*Change
+	// the current operation
+	method, path string
+	tags         []string
+}
+
+func (d *differ) report(breaking bool, kind string, location []string, format string, args ...interface{}) {
+	d.changes = append(d.changes, &Change{
+		Breaking: breaking,
+		Kind:     kind,
+		Method:   d.method,
+		Path:     d.path,
+		Tags:     d.tags,
+		Location: (&Difference{Path: location}).Pointer(),
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+func (d *differ) operations() {
+	fromPaths, _ := d.from["paths"].(map[string]interface{})
+	toPaths, _ := d.to["paths"].(map[string]interface{})
+	for _, p := range unionKeys(fromPaths, toPaths) {
+		fromItem, _ := fromPaths[p].(map[string]interface{})
+		toItem, _ := toPaths[p].(map[string]interface{})
+		for _, method := range Methods {
+			fromOp, _ := fromItem[method].(map[string]interface{})
+			toOp, _ := toItem[method].(map[string]interface{})
+			d.method, d.path = method, p
+			location := []string{"paths", p, method}
+			switch {
+			case fromOp == nil && toOp == nil:
+				continue
+			case fromOp == nil:
+				d.tags = stringList(toOp["tags"])
+				d.report(false, Added, location, "operation added")
+			case toOp == nil:
+				d.tags = stringList(fromOp["tags"])
+				d.report(true, Removed, location, "operation removed")
+			default:
+				d.tags = stringList(toOp["tags"])
+				d.operation(location, fromItem, toItem, fromOp, toOp)
+			}
+		}
+	}
+	d.method, d.path, d.tags = "", "", nil
+}
+
+func (d *differ) operation(location []string, fromItem, toItem, fromOp, toOp map[string]interface{}) {
+	if fromOp["deprecated"] != true && toOp["deprecated"] == true {
+		d.report(false, Changed, append(location, "deprecated"), "operation deprecated")
+	}
+
+	fromParams := d.params(d.from, location, fromItem, fromOp)
+	toParams := d.params(d.to, location, toItem, toOp)
+	for _, key := range unionParamKeys(fromParams, toParams) {
+		fp, tp := fromParams[key], toParams[key]
+		switch {
+		case fp == nil:
+			if tp.obj["required"] == true {
+				d.report(true, Added, tp.location, "required param(%s) added", key)
+			} else {
+				d.report(false, Added, tp.location, "optional param(%s) added", key)
+			}
+		case tp == nil:
+			d.report(false, Removed, fp.location, "param(%s) removed", key)
+		default:
+			if fp.obj["required"] != true && tp.obj["required"] == true {
+				d.report(true, Changed, tp.location, "param(%s) becomes required", key)
+			}
+			if fp.obj["in"] == "body" {
+				fs, _ := fp.obj["schema"].(map[string]interface{})
+				ts, _ := tp.obj["schema"].(map[string]interface{})
+				d.schema(append(tp.location, "schema"), key, fs, ts, true, map[string]bool{})
+			} else {
+				d.schema(tp.location, key, fp.obj, tp.obj, true, map[string]bool{})
+			}
+		}
+	}
+
+	fromResps, _ := fromOp["responses"].(map[string]interface{})
+	toResps, _ := toOp["responses"].(map[string]interface{})
+	for _, code := range unionKeys(fromResps, toResps) {
+		fr, _ := fromResps[code].(map[string]interface{})
+		tr, _ := toResps[code].(map[string]interface{})
+		rloc := append(location, "responses", code)
+		switch {
+		case fr == nil:
+			d.report(false, Added, rloc, "response(%s) added", code)
+		case tr == nil:
+			d.report(true, Removed, rloc, "response(%s) removed", code)
+		default:
+			fs, _ := fr["schema"].(map[string]interface{})
+			ts, _ := tr["schema"].(map[string]interface{})
+			switch {
+			case fs == nil && ts != nil:
+				d.report(false, Added, append(rloc, "schema"), "response(%s) schema added", code)
+			case fs != nil && ts == nil:
+				d.report(true, Removed, append(rloc, "schema"), "response(%s) schema removed", code)
+			default:
+				d.schema(append(rloc, "schema"), "response("+code+")", fs, ts, false, map[string]bool{})
+			}
+			d.representations(rloc, code, fr, tr)
+		}
+	}
+}
+
+// representations compare the representations of response in media types (`x-content`)
+func (d *differ) representations(location []string, code string, fr, tr map[string]interface{}) {
+	fc, _ := fr["x-content"].(map[string]interface{})
+	tc, _ := tr["x-content"].(map[string]interface{})
+	for _, t := range unionKeys(fc, tc) {
+		loc := append(append([]string{}, location...), "x-content", t)
+		fm, _ := fc[t].(map[string]interface{})
+		tm, _ := tc[t].(map[string]interface{})
+		switch {
+		case fm == nil:
+			d.report(false, Added, loc, "representation(%s) of response(%s) added", t, code)
+		case tm == nil:
+			d.report(true, Removed, loc, "representation(%s) of response(%s) removed", t, code)
+		default:
+			fs, _ := fm["schema"].(map[string]interface{})
+			ts, _ := tm["schema"].(map[string]interface{})
+			name := fmt.Sprintf("response(%s) in %s", code, t)
+			switch {
+			case reflect.DeepEqual(fs, fr["schema"]) && reflect.DeepEqual(ts, tr["schema"]):
+				// the schema of response has been compared
+			case fs == nil && ts != nil:
+				d.report(false, Added, append(loc, "schema"), "%s schema added", name)
+			case fs != nil && ts == nil:
+				d.report(true, Removed, append(loc, "schema"), "%s schema removed", name)
+			default:
+				d.schema(append(loc, "schema"), name, fs, ts, false, map[string]bool{})
+			}
+		}
+	}
+}
+
+type param struct {
+	obj      map[string]interface{}
+	location []string
+}
+
+// params collect the params of operation and path item by `in.name`
+func (d *differ) params(doc map[string]interface{}, location []string, item, op map[string]interface{}) map[string]*param {
+	params := map[string]*param{}
+	// the operation's params override the path item's
+	for i, list := range []interface{}{item["parameters"], op["parameters"]} {
+		loc := location
+		if i == 0 {
+			loc = location[:len(location)-1]
+		}
+		items, _ := list.([]interface{})
+		for idx, v := range items {
+			obj, _ := resolveRef(doc, v)
+			if obj != nil {
+				params[fmt.Sprint(obj["in"], ".", obj["name"])] = &param{
+					obj:      obj,
+					location: append(append([]string{}, loc...), "parameters", fmt.Sprint(idx)),
+				}
+			}
+		}
+	}
+	return params
+}
+
+// schema compare the schemas(or non-body params) in request or response
+func (d *differ) schema(location []string, name string, from, to map[string]interface{}, request bool, visited map[string]bool) {
+	if from == nil || to == nil {
+		return
+	}
+	// break the circular references
+	refs := fmt.Sprint(from["$ref"], "=>", to["$ref"])
+	if from["$ref"] != nil || to["$ref"] != nil {
+		if visited[refs] {
+			return
+		}
+		visited[refs] = true
+	}
+	from, _ = resolveRef(d.from, from)
+	to, _ = resolveRef(d.to, to)
+	if from == nil || to == nil {
+		return
+	}
+
+	if ft, tt := typeFormat(from), typeFormat(to); ft != tt {
+		d.report(true, Changed, location, "type of %s changed from %s to %s", name, ft, tt)
+		return
+	}
+	fe, fromEnum := from["enum"].([]interface{})
+	te, toEnum := to["enum"].([]interface{})
+	switch {
+	case fromEnum && toEnum:
+		if removed := subtract(fe, te); len(removed) != 0 && request {
+			d.report(true, Changed, append(location, "enum"), "enum of %s narrowed, %s removed", name, jsonString(removed))
+		} else if len(removed) != 0 || len(subtract(te, fe)) != 0 {
+			d.report(false, Changed, append(location, "enum"), "enum of %s changed from %s to %s", name, jsonString(fe), jsonString(te))
+		}
+	case fromEnum:
+		d.report(false, Removed, append(location, "enum"), "enum of %s removed", name)
+	case toEnum:
+		d.report(request, Added, append(location, "enum"), "enum of %s narrowed to %s", name, jsonString(te))
+	}
+
+	fromRequired := map[string]bool{}
+	for _, r := range stringList(from["required"]) {
+		fromRequired[r] = true
+	}
+	toRequired := map[string]bool{}
+	for _, r := range stringList(to["required"]) {
+		toRequired[r] = true
+	}
+	fromProps, _ := from["properties"].(map[string]interface{})
+	toProps, _ := to["properties"].(map[string]interface{})
+	for _, key := range unionKeys(fromProps, toProps) {
+		fp, _ := fromProps[key].(map[string]interface{})
+		tp, _ := toProps[key].(map[string]interface{})
+		ploc := append(append([]string{}, location...), "properties", key)
+		switch {
+		case fp == nil:
+			if request && toRequired[key] {
+				d.report(true, Added, ploc, "required request field(%s) added", key)
+			} else {
+				d.report(false, Added, ploc, "field(%s) added", key)
+			}
+		case tp == nil:
+			if request {
+				d.report(false, Removed, ploc, "request field(%s) removed", key)
+			} else {
+				d.report(true, Removed, ploc, "response field(%s) removed", key)
+			}
+		default:
+			if request && !fromRequired[key] && toRequired[key] {
+				d.report(true, Changed, ploc, "request field(%s) becomes required", key)
+			}
+			d.schema(ploc, key, fp, tp, request, visited)
+		}
+	}
+
+	for _, key := range []string{"items", "additionalProperties"} {
+		fi, _ := from[key].(map[string]interface{})
+		ti, _ := to[key].(map[string]interface{})
+		d.schema(append(append([]string{}, location...), key), name, fi, ti, request, visited)
+	}
+}
+
+func (d *differ) definitions() {
+	fromDefs, _ := d.from["definitions"].(map[string]interface{})
+	toDefs, _ := d.to["definitions"].(map[string]interface{})
+	for _, name := range unionKeys(fromDefs, toDefs) {
+		location := (&Difference{Path: []string{"definitions", name}}).Pointer()
+		c := &Change{Definition: name, Location: location}
+		switch fd, td := fromDefs[name], toDefs[name]; {
+		case fd == nil:
+			c.Kind, c.Message = Added, "definition added"
+		case td == nil:
+			c.Kind, c.Message = Removed, "definition removed"
+		case len(Compare(fd, td)) != 0:
+			c.Kind, c.Message = Changed, "definition changed"
+		default:
+			continue
+		}
+		d.changes = append(d.changes, c)
+	}
+}
+
+// resolveRef resolve the local `$ref` of object
+func resolveRef(doc map[string]interface{}, v interface{}) (map[string]interface{}, string) {
+	obj, _ := v.(map[string]interface{})
+	ref, ok := obj["$ref"].(string)
+	if !ok {
+		return obj, ""
+	}
+	resolved, _ := lookupPointer(doc, ref)
+	obj, _ = resolved.(map[string]interface{})
+	return obj, ref
+}
+
+func typeFormat(s map[string]interface{}) string {
+	t, _ := s["type"].(string)
+	if f, _ := s["format"].(string); f != "" {
+		return t + "(" + f + ")"
+	}
+	return t
+}
+
+// subtract the values in a but not in b
+func subtract(a, b []interface{}) (r []interface{}) {
+	for _, v := range a {
+		found := false
+		for _, w := range b {
+			if reflect.DeepEqual(v, w) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			r = append(r, v)
+		}
+	}
+	return
+}
+
+func stringList(v interface{}) (r []string) {
+	list, _ := v.([]interface{})
+	for _, s := range list {
+		r = append(r, fmt.Sprint(s))
+	}
+	return
+}
+
+func unionKeys(a, b map[string]interface{}) []string {
+	m := map[string]interface{}{}
+	for k := range a {
+		m[k] = nil
+	}
+	for k := range b {
+		m[k] = nil
+	}
+	return sortedKeys(m)
+}
+
+func unionParamKeys(a, b map[string]*param) []string {
+	m := map[string]interface{}{}
+	for k := range a {
+		m[k] = nil
+	}
+	for k := range b {
+		m[k] = nil
+	}
+	return sortedKeys(m)
+}
@@ -0,0 +1,235 @@
+package swagger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	assert := assert.New(t)
+
+	from, err := Unmarshal([]byte(`
+swagger: "2.0"
+info: {title: test, version: "1.0.0"}
+paths:
+  /users:
+    get:
+      tags: [user]
+      parameters:
+      - {name: sort, in: query, type: string, enum: [asc, desc]}
+      responses:
+        200: {description: OK, schema: {type: array, items: {$ref: "#/definitions/User"}}}
+    post:
+      tags: [user]
+      parameters:
+      - {name: body, in: body, schema: {$ref: "#/definitions/User"}}
+      responses:
+        200: {description: OK}
+  /teams:
+    get:
+      tags: [team]
+      responses:
+        200: {description: OK}
+definitions:
+  User:
+    type: object
+    properties:
+      id: {type: string}
+      age: {type: integer}
+`))
+	assert.Nil(err)
+	to, err := Unmarshal([]byte(`
+swagger: "2.0"
+info: {title: test, version: "1.1.0"}
+paths:
+  /users:
+    get:
+      tags: [user]
+      parameters:
+      - {name: sort, in: query, type: string, enum: [asc]}
+      - {name: limit, in: query, type: integer, required: true}
+      responses:
+        200: {description: OK, schema: {type: array, items: {$ref: "#/definitions/User"}}}
+    post:
+      tags: [user]
+      parameters:
+      - {name: body, in: body, schema: {$ref: "#/definitions/User"}}
+      responses:
+        200: {description: OK}
+  /projects:
+    get:
+      tags: [project]
+      responses:
+        200: {description: OK}
+definitions:
+  User:
+    type: object
+    required: [name]
+    properties:
+      id: {type: integer}
+      name: {type: string}
+`))
+	assert.Nil(err)
+
+	assert.Empty(Diff(from, from))
+
+	messages := []string{}
+	for _, c := range Diff(from, to) {
+		messages = append(messages, c.String())
+	}
+	assert.Equal([]string{
+		"[non-breaking] GET /projects: operation added",
+		"[breaking] GET /teams: operation removed",
+		"[breaking] GET /users: required param(query.limit) added",
+		"[breaking] GET /users: enum of query.sort narrowed, [\"desc\"] removed",
+		"[breaking] GET /users: response field(age) removed",
+		"[breaking] GET /users: type of id changed from string to integer",
+		"[non-breaking] GET /users: field(name) added",
+		"[non-breaking] POST /users: request field(age) removed",
+		"[breaking] POST /users: type of id changed from string to integer",
+		"[breaking] POST /users: required request field(name) added",
+		"[non-breaking] User: definition changed",
+	}, messages)
+	assert.True(HasBreaking(Diff(from, to)))
+	assert.Equal("#/paths/~1users/get/parameters/1", Diff(from, to)[2].Location)
+}
+
+func TestDiffObjectEnum(t *testing.T) {
+	assert := assert.New(t)
+
+	from, err := Unmarshal([]byte(`
+swagger: "2.0"
+info: {title: test, version: "1.0.0"}
+paths:
+  /users:
+    post:
+      parameters:
+      - {name: body, in: body, schema: {type: object, enum: [{a: 1}, {b: 2}]}}
+      responses:
+        200: {description: OK}
+`))
+	assert.Nil(err)
+	to, err := Unmarshal([]byte(`
+swagger: "2.0"
+info: {title: test, version: "1.0.0"}
+paths:
+  /users:
+    post:
+      parameters:
+      - {name: body, in: body, schema: {type: object, enum: [{a: 1}]}}
+      responses:
+        200: {description: OK}
+`))
+	assert.Nil(err)
+
+	assert.Empty(Diff(from, from))
+	changes := Diff(from, to)
+	assert.Equal(1, len(changes))
+	assert.Equal("[breaking] POST /users: enum of body.body narrowed, [{\"b\":2}] removed", changes[0].String())
+}
+
+func TestDiffRepresentations(t *testing.T) {
+	assert := assert.New(t)
+
+	from, err := Unmarshal([]byte(`
+swagger: "2.0"
+info: {title: test, version: "1.0.0"}
+paths:
+  /users:
+    get:
+      responses:
+        200:
+          description: OK
+          schema: {type: object, properties: {id: {type: string}}}
+          x-content:
+            application/json: {schema: {type: object, properties: {id: {type: string}}}}
+            text/csv: {schema: {type: string}}
+            application/x-protobuf: {schema: {type: string, format: binary}}
+`))
+	assert.Nil(err)
+	to, err := Unmarshal([]byte(`
+swagger: "2.0"
+info: {title: test, version: "1.0.0"}
+paths:
+  /users:
+    get:
+      responses:
+        200:
+          description: OK
+          schema: {type: object, properties: {id: {type: integer}}}
+          x-content:
+            application/json: {schema: {type: object, properties: {id: {type: integer}}}}
+            text/csv: {schema: {type: integer}}
+            application/xml: {schema: {type: string}}
+`))
+	assert.Nil(err)
+
+	messages := []string{}
+	for _, c := range Diff(from, to) {
+		messages = append(messages, c.String())
+	}
+	assert.Equal([]string{
+		"[breaking] GET /users: type of id changed from string to integer",
+		"[breaking] GET /users: representation(application/x-protobuf) of response(200) removed",
+		"[non-breaking] GET /users: representation(application/xml) of response(200) added",
+		"[breaking] GET /users: type of response(200) in text/csv changed from string to integer",
+	}, messages)
+}
+
+func TestChangelog(t *testing.T) {
+	assert := assert.New(t)
+
+	from, err := Unmarshal([]byte(`
+paths:
+  /users:
+    get:
+      tags: [user]
+      responses:
+        200: {description: OK, schema: {$ref: "#/definitions/User"}}
+  /teams:
+    get:
+      tags: [team]
+      responses:
+        200: {description: OK}
+definitions:
+  User: {type: object, properties: {name: {type: string}}}
+`))
+	assert.Nil(err)
+	to, err := Unmarshal([]byte(`
+paths:
+  /users:
+    get:
+      tags: [user]
+      parameters:
+      - {name: limit, in: query, type: integer}
+      responses:
+        200: {description: OK, schema: {$ref: "#/definitions/User"}}
+    post:
+      tags: [user]
+      responses:
+        200: {description: OK}
+definitions:
+  User: {type: object, properties: {name: {type: string}, age: {type: integer}}}
+  Unused: {type: object}
+`))
+	assert.Nil(err)
+
+	assert.Equal("# v1\n\nNo API changes.\n", Changelog("v1", from, from))
+	assert.Equal("# v1...v2\n"+
+		"\n## team\n"+
+		"\n### Removed\n\n"+
+		"- `GET /teams` **(breaking)**\n"+
+		"\n## user\n"+
+		"\n### Added\n\n"+
+		"- `POST /users`\n"+
+		"\n### Changed\n\n"+
+		"- `GET /users`\n"+
+		"  - optional param(query.limit) added\n"+
+		"  - field(age) added\n"+
+		"\n### Models\n\n"+
+		"- Changed `User`\n"+
+		"\n## Others\n"+
+		"\n### Models\n\n"+
+		"- Added `Unused`\n", Changelog("v1...v2", from, to))
+}
@@ -0,0 +1,77 @@
+package swagger
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Unmarshal decode the swagger document(json or yaml) to generic values
+// objects are map[string]interface{}, arrays are []interface{}
+// and all the numbers are float64 like encoding/json does
+func Unmarshal(data []byte) (interface{}, error) {
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err == nil {
+		return doc, nil
+	}
+	// json is a subset of yaml
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return normalize(doc), nil
+}
+
+// ReadFile read and decode the swagger document from file
+func ReadFile(filename string) (interface{}, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := Unmarshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("decode file(%s) error(%v)", filename, err)
+	}
+	return doc, nil
+}
+
+// ToDocument convert the swagger object to generic values
+func (s *Swagger) ToDocument() (interface{}, error) {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	var doc interface{}
+	err = json.Unmarshal(data, &doc)
+	return doc, err
+}
+
+// normalize convert the values decoded by yaml to the same as encoding/json
+func normalize(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(t))
+		for k, v := range t {
+			m[fmt.Sprint(k)] = normalize(v)
+		}
+		return m
+	case map[string]interface{}:
+		for k, v := range t {
+			t[k] = normalize(v)
+		}
+	case []interface{}:
+		for i, v := range t {
+			t[i] = normalize(v)
+		}
+	case int:
+		return float64(t)
+	case int64:
+		return float64(t)
+	case uint64:
+		return float64(t)
+	case float32:
+		return float64(t)
+	}
+	return v
+}
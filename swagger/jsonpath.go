@@ -0,0 +1,310 @@
+package swagger
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// jsonPath the compiled JSONPath expression, it supports
+// `$`, `.name`, `['name']`, `[0]`, `[*]`, `..name` and the filters like `[?(@.name == 'value')]`
+type jsonPath []*pathSegment
+
+type pathSegment struct {
+	recursive bool // `..`, select from all the descendants
+	wildcard  bool
+	names     []string
+	indexes   []int
+	filter    *pathFilter
+}
+
+// pathFilter the filter expression like `@.a.b == 'value'`,
+// tests the existence of value if op is empty
+type pathFilter struct {
+	path  []string
+	op    string
+	value interface{}
+}
+
+// pathNode the value selected by JSONPath
+// and its location, the elements are string keys or int indexes
+type pathNode struct {
+	value    interface{}
+	location []interface{}
+}
+
+// compileJSONPath compile the JSONPath expression
+func compileJSONPath(expr string) (jsonPath, error) {
+	if !strings.HasPrefix(expr, "$") {
+		return nil, fmt.Errorf("JSONPath(%s) should start with `$`", expr)
+	}
+	p := jsonPath{}
+	s := expr[1:]
+	for s != "" {
+		seg := &pathSegment{}
+		switch {
+		case strings.HasPrefix(s, ".."):
+			seg.recursive = true
+			s = s[2:]
+			if strings.HasPrefix(s, "[") {
+				break
+			}
+			fallthrough
+		case strings.HasPrefix(s, "."):
+			s = strings.TrimPrefix(s, ".")
+			end := strings.IndexAny(s, ".[")
+			if end == -1 {
+				end = len(s)
+			}
+			name := s[:end]
+			s = s[end:]
+			if name == "" {
+				return nil, fmt.Errorf("JSONPath(%s) has an empty name", expr)
+			}
+			if name == "*" {
+				seg.wildcard = true
+			} else {
+				seg.names = []string{name}
+			}
+			p = append(p, seg)
+			continue
+		case strings.HasPrefix(s, "["):
+		default:
+			return nil, fmt.Errorf("JSONPath(%s) has an unexpected character at (%s)", expr, s)
+		}
+		end := closingBracket(s)
+		if end == -1 {
+			return nil, fmt.Errorf("JSONPath(%s) has an unclosed bracket", expr)
+		}
+		if err := seg.parseSelector(strings.TrimSpace(s[1:end])); err != nil {
+			return nil, fmt.Errorf("JSONPath(%s) error(%v)", expr, err)
+		}
+		s = s[end+1:]
+		p = append(p, seg)
+	}
+	return p, nil
+}
+
+// closingBracket the index of the bracket which closes the first one, skips the quoted strings
+func closingBracket(s string) int {
+	var quote rune
+	depth := 0
+	for i, r := range s {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"':
+			quote = r
+		case r == '[' || r == '(':
+			depth++
+		case r == ']' || r == ')':
+			if depth--; depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// parseSelector parse the selector in brackets
+func (seg *pathSegment) parseSelector(s string) error {
+	switch {
+	case s == "*":
+		seg.wildcard = true
+		return nil
+	case strings.HasPrefix(s, "?"):
+		return seg.parseFilter(strings.TrimSpace(s[1:]))
+	}
+	for _, item := range splitSelector(s) {
+		item = strings.TrimSpace(item)
+		if v, err := literal(item); err == nil {
+			switch t := v.(type) {
+			case string:
+				seg.names = append(seg.names, t)
+				continue
+			case float64:
+				if t == float64(int(t)) {
+					seg.indexes = append(seg.indexes, int(t))
+					continue
+				}
+			}
+		}
+		return fmt.Errorf("invalid selector(%s)", item)
+	}
+	return nil
+}
+
+// parseFilter parse the filter expression like `(@.name == 'value')`
+func (seg *pathSegment) parseFilter(s string) error {
+	if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+		s = strings.TrimSpace(s[1 : len(s)-1])
+	}
+	f := &pathFilter{}
+	operand := s
+	for _, op := range []string{"==", "!="} {
+		if i := strings.Index(s, op); i != -1 {
+			v, err := literal(strings.TrimSpace(s[i+len(op):]))
+			if err != nil {
+				return err
+			}
+			f.op, f.value, operand = op, v, strings.TrimSpace(s[:i])
+			break
+		}
+	}
+	if operand != "@" && !strings.HasPrefix(operand, "@.") {
+		return fmt.Errorf("filter(%s) should be like `@.name == 'value'`", s)
+	}
+	if operand != "@" {
+		f.path = strings.Split(operand[2:], ".")
+	}
+	seg.filter = f
+	return nil
+}
+
+// splitSelector split the selector by commas which are not quoted
+func splitSelector(s string) []string {
+	items := []string{}
+	var quote rune
+	start := 0
+	for i, r := range s {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"':
+			quote = r
+		case r == ',':
+			items = append(items, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(items, s[start:])
+}
+
+// literal parse the literal in JSONPath, like `'name'`, `"name"`, `1`, `true` and `null`
+func literal(s string) (interface{}, error) {
+	switch {
+	case len(s) >= 2 && (s[0] == '\'' || s[0] == '"') && s[len(s)-1] == s[0]:
+		return s[1 : len(s)-1], nil
+	case s == "true":
+		return true, nil
+	case s == "false":
+		return false, nil
+	case s == "null":
+		return nil, nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid literal(%s)", s)
+	}
+	return f, nil
+}
+
+// find select the nodes of doc by JSONPath
+func (p jsonPath) find(doc interface{}) []*pathNode {
+	nodes := []*pathNode{&pathNode{value: doc}}
+	for _, seg := range p {
+		if seg.recursive {
+			all := []*pathNode{}
+			for _, n := range nodes {
+				all = append(all, n.descendants()...)
+			}
+			nodes = all
+		}
+		selected := []*pathNode{}
+		for _, n := range nodes {
+			selected = append(selected, seg.children(n)...)
+		}
+		nodes = selected
+	}
+	return nodes
+}
+
+// descendants the node and all its descendants in preorder
+func (n *pathNode) descendants() []*pathNode {
+	nodes := []*pathNode{n}
+	for _, child := range n.children() {
+		nodes = append(nodes, child.descendants()...)
+	}
+	return nodes
+}
+
+// children the children of node, the keys of object are sorted
+func (n *pathNode) children() []*pathNode {
+	nodes := []*pathNode{}
+	switch t := n.value.(type) {
+	case map[string]interface{}:
+		for _, k := range sortedKeys(t) {
+			nodes = append(nodes, n.child(t[k], k))
+		}
+	case []interface{}:
+		for i, v := range t {
+			nodes = append(nodes, n.child(v, i))
+		}
+	}
+	return nodes
+}
+
+func (n *pathNode) child(v interface{}, key interface{}) *pathNode {
+	location := make([]interface{}, len(n.location), len(n.location)+1)
+	copy(location, n.location)
+	return &pathNode{value: v, location: append(location, key)}
+}
+
+// children select the children of node by the segment
+func (seg *pathSegment) children(n *pathNode) []*pathNode {
+	if seg.wildcard {
+		return n.children()
+	}
+	if seg.filter != nil {
+		nodes := []*pathNode{}
+		for _, child := range n.children() {
+			if seg.filter.match(child.value) {
+				nodes = append(nodes, child)
+			}
+		}
+		return nodes
+	}
+	nodes := []*pathNode{}
+	switch t := n.value.(type) {
+	case map[string]interface{}:
+		for _, name := range seg.names {
+			if v, ok := t[name]; ok {
+				nodes = append(nodes, n.child(v, name))
+			}
+		}
+	case []interface{}:
+		for _, i := range seg.indexes {
+			if i < 0 {
+				i += len(t)
+			}
+			if i >= 0 && i < len(t) {
+				nodes = append(nodes, n.child(t[i], i))
+			}
+		}
+	}
+	return nodes
+}
+
+func (f *pathFilter) match(v interface{}) bool {
+	for _, name := range f.path {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		if v, ok = m[name]; !ok {
+			return false
+		}
+	}
+	switch f.op {
+	case "==":
+		return reflect.DeepEqual(v, f.value)
+	case "!=":
+		return !reflect.DeepEqual(v, f.value)
+	}
+	return true
+}
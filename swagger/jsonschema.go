@@ -0,0 +1,398 @@
+package swagger
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// schemaValidator a tiny JSON Schema(draft-04) validator
+// which supports the keywords used by the bundled schemas
+type schemaValidator struct {
+	// schema id -> schema document
+	roots map[string]interface{}
+	// the compiled patterns, it's shared by the concurrent validations
+	mu      sync.Mutex
+	regexps map[string]*regexp.Regexp
+}
+
+func newSchemaValidator(schemas map[string]string) (*schemaValidator, error) {
+	v := &schemaValidator{
+		roots:   map[string]interface{}{},
+		regexps: map[string]*regexp.Regexp{},
+	}
+	for id, s := range schemas {
+		var doc interface{}
+		if err := json.Unmarshal([]byte(s), &doc); err != nil {
+			return nil, fmt.Errorf("bundled schema(%s) error(%v)", id, err)
+		}
+		v.roots[id] = doc
+	}
+	return v, nil
+}
+
+// validate check the value with the root schema
+func (v *schemaValidator) validate(rootID string, value interface{}) []*ValidationError {
+	errs := []*ValidationError{}
+	v.check(rootID, v.roots[rootID], value, "#", &errs)
+	return errs
+}
+
+func (v *schemaValidator) check(rootID string, schema, value interface{}, path string, errs *[]*ValidationError) {
+	s, ok := schema.(map[string]interface{})
+	if !ok {
+		// `true` or `{}` schema
+		return
+	}
+	if ref, ok := s["$ref"].(string); ok {
+		id, sub, err := v.resolve(rootID, ref)
+		if err != nil {
+			*errs = append(*errs, &ValidationError{path, err.Error()})
+			return
+		}
+		v.check(id, sub, value, path, errs)
+		return
+	}
+
+	report := func(format string, args ...interface{}) {
+		*errs = append(*errs, &ValidationError{path, fmt.Sprintf(format, args...)})
+	}
+
+	if t, ok := s["type"]; ok && !matchType(t, value) {
+		report("should be %s, not %s", typeNames(t), typeOf(value))
+		return
+	}
+	if enum, ok := s["enum"].([]interface{}); ok {
+		found := false
+		for _, e := range enum {
+			if reflect.DeepEqual(e, value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			report("should be one of %s", jsonString(enum))
+		}
+	}
+
+	switch t := value.(type) {
+	case map[string]interface{}:
+		v.checkObject(rootID, s, t, path, errs)
+	case []interface{}:
+		v.checkArray(rootID, s, t, path, errs)
+	case string:
+		if n, ok := s["minLength"].(float64); ok && float64(len([]rune(t))) < n {
+			report("should not be shorter than %v characters", n)
+		}
+		if n, ok := s["maxLength"].(float64); ok && float64(len([]rune(t))) > n {
+			report("should not be longer than %v characters", n)
+		}
+		if p, ok := s["pattern"].(string); ok {
+			if re := v.regexp(p); re != nil && !re.MatchString(t) {
+				report("should match pattern %q", p)
+			}
+		}
+	case float64:
+		if n, ok := s["minimum"].(float64); ok {
+			if exclusive, _ := s["exclusiveMinimum"].(bool); exclusive && t <= n {
+				report("should be greater than %v", n)
+			} else if t < n {
+				report("should be greater than or equal to %v", n)
+			}
+		}
+		if n, ok := s["maximum"].(float64); ok {
+			if exclusive, _ := s["exclusiveMaximum"].(bool); exclusive && t >= n {
+				report("should be less than %v", n)
+			} else if t > n {
+				report("should be less than or equal to %v", n)
+			}
+		}
+		if n, ok := s["multipleOf"].(float64); ok && n > 0 {
+			if q := t / n; math.Abs(q-math.Floor(q+0.5)) > 1e-9 {
+				report("should be a multiple of %v", n)
+			}
+		}
+	}
+
+	if all, ok := s["allOf"].([]interface{}); ok {
+		for _, sub := range all {
+			v.check(rootID, sub, value, path, errs)
+		}
+	}
+	if anyOf, ok := s["anyOf"].([]interface{}); ok {
+		if matched, best := v.match(rootID, anyOf, value, path); matched == 0 {
+			// the errors of nearest schema are more readable
+			*errs = append(*errs, best...)
+		}
+	}
+	if oneOf, ok := s["oneOf"].([]interface{}); ok {
+		switch matched, best := v.match(rootID, oneOf, value, path); {
+		case matched == 0:
+			*errs = append(*errs, best...)
+		case matched > 1:
+			report("should match exactly one of the schemas, but matched %d", matched)
+		}
+	}
+	if not, ok := s["not"]; ok {
+		sub := []*ValidationError{}
+		v.check(rootID, not, value, path, &sub)
+		if len(sub) == 0 {
+			report("should not match the schema %s", jsonString(not))
+		}
+	}
+}
+
+// match count the matched schemas and
+// returns the errors of the nearest one if nothing is matched
+func (v *schemaValidator) match(rootID string, schemas []interface{}, value interface{}, path string) (matched int, best []*ValidationError) {
+	for _, sub := range schemas {
+		errs := []*ValidationError{}
+		v.check(rootID, sub, value, path, &errs)
+		if len(errs) == 0 {
+			matched++
+		} else if best == nil || len(errs) < len(best) {
+			best = errs
+		}
+	}
+	return
+}
+
+func (v *schemaValidator) checkObject(rootID string, s, obj map[string]interface{}, path string, errs *[]*ValidationError) {
+	if n, ok := s["minProperties"].(float64); ok && float64(len(obj)) < n {
+		*errs = append(*errs, &ValidationError{path, fmt.Sprintf("should not have fewer than %v properties", n)})
+	}
+	if n, ok := s["maxProperties"].(float64); ok && float64(len(obj)) > n {
+		*errs = append(*errs, &ValidationError{path, fmt.Sprintf("should not have more than %v properties", n)})
+	}
+	if required, ok := s["required"].([]interface{}); ok {
+		for _, r := range required {
+			name, _ := r.(string)
+			if _, ok := obj[name]; !ok {
+				*errs = append(*errs, &ValidationError{path, fmt.Sprintf("missing required property %q", name)})
+			}
+		}
+	}
+	if deps, ok := s["dependencies"].(map[string]interface{}); ok {
+		for name, dep := range deps {
+			if _, ok := obj[name]; !ok {
+				continue
+			}
+			if names, ok := dep.([]interface{}); ok {
+				for _, n := range names {
+					if _, ok := obj[n.(string)]; !ok {
+						*errs = append(*errs, &ValidationError{path, fmt.Sprintf("property %q requires property %q", name, n)})
+					}
+				}
+			} else {
+				v.check(rootID, dep, obj, path, errs)
+			}
+		}
+	}
+
+	props, _ := s["properties"].(map[string]interface{})
+	patterns, _ := s["patternProperties"].(map[string]interface{})
+	additional, hasAdditional := s["additionalProperties"]
+	for _, key := range sortedKeys(obj) {
+		value := obj[key]
+		childPath := path + "/" + escapePointer(key)
+		matched := false
+		if sub, ok := props[key]; ok {
+			matched = true
+			v.check(rootID, sub, value, childPath, errs)
+		}
+		for _, p := range sortedKeys(patterns) {
+			if re := v.regexp(p); re != nil && re.MatchString(key) {
+				matched = true
+				v.check(rootID, patterns[p], value, childPath, errs)
+			}
+		}
+		if matched || !hasAdditional {
+			continue
+		}
+		if allowed, ok := additional.(bool); ok {
+			if !allowed {
+				*errs = append(*errs, &ValidationError{path, fmt.Sprintf("property %q is not allowed", key)})
+			}
+			continue
+		}
+		v.check(rootID, additional, value, childPath, errs)
+	}
+}
+
+func (v *schemaValidator) checkArray(rootID string, s map[string]interface{}, arr []interface{}, path string, errs *[]*ValidationError) {
+	if n, ok := s["minItems"].(float64); ok && float64(len(arr)) < n {
+		*errs = append(*errs, &ValidationError{path, fmt.Sprintf("should not have fewer than %v items", n)})
+	}
+	if n, ok := s["maxItems"].(float64); ok && float64(len(arr)) > n {
+		*errs = append(*errs, &ValidationError{path, fmt.Sprintf("should not have more than %v items", n)})
+	}
+	if unique, _ := s["uniqueItems"].(bool); unique {
+	loop:
+		for i := range arr {
+			for j := 0; j < i; j++ {
+				if reflect.DeepEqual(arr[i], arr[j]) {
+					*errs = append(*errs, &ValidationError{path, fmt.Sprintf("items %d and %d should be unique", j, i)})
+					break loop
+				}
+			}
+		}
+	}
+	switch items := s["items"].(type) {
+	case map[string]interface{}:
+		for i, item := range arr {
+			v.check(rootID, items, item, fmt.Sprintf("%s/%d", path, i), errs)
+		}
+	case []interface{}:
+		for i, item := range arr {
+			if i < len(items) {
+				v.check(rootID, items[i], item, fmt.Sprintf("%s/%d", path, i), errs)
+				continue
+			}
+			if allowed, ok := s["additionalItems"].(bool); ok && !allowed {
+				*errs = append(*errs, &ValidationError{path, fmt.Sprintf("should not have more than %d items", len(items))})
+				break
+			}
+			if additional, ok := s["additionalItems"].(map[string]interface{}); ok {
+				v.check(rootID, additional, item, fmt.Sprintf("%s/%d", path, i), errs)
+			}
+		}
+	}
+}
+
+// resolve find the schema by `$ref`
+func (v *schemaValidator) resolve(rootID, ref string) (string, interface{}, error) {
+	id := rootID
+	pointer := ref
+	if idx := strings.Index(ref, "#"); idx > 0 {
+		id, pointer = ref[:idx], ref[idx:]
+	} else if idx < 0 {
+		id, pointer = ref, "#"
+	}
+	root, ok := v.roots[id]
+	if !ok {
+		return "", nil, fmt.Errorf("unknown schema(%s)", ref)
+	}
+	sub, ok := lookupPointer(root, pointer)
+	if !ok {
+		return "", nil, fmt.Errorf("unresolvable schema reference(%s)", ref)
+	}
+	return id, sub, nil
+}
+
+func (v *schemaValidator) regexp(pattern string) *regexp.Regexp {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	re, ok := v.regexps[pattern]
+	if !ok {
+		// invalid patterns are ignored
+		re, _ = regexp.Compile(pattern)
+		v.regexps[pattern] = re
+	}
+	return re
+}
+
+// lookupPointer find the value in document by JSON pointer like `#/definitions/User`
+func lookupPointer(doc interface{}, pointer string) (interface{}, bool) {
+	pointer = strings.TrimPrefix(pointer, "#")
+	if pointer == "" {
+		return doc, true
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, false
+	}
+	cur := doc
+	for _, token := range strings.Split(pointer[1:], "/") {
+		token = unescapePointer(token)
+		switch t := cur.(type) {
+		case map[string]interface{}:
+			v, ok := t[token]
+			if !ok {
+				return nil, false
+			}
+			cur = v
+		case []interface{}:
+			var i int
+			if _, err := fmt.Sscanf(token, "%d", &i); err != nil || i < 0 || i >= len(t) {
+				return nil, false
+			}
+			cur = t[i]
+		default:
+			return nil, false
+		}
+	}
+	return cur, true
+}
+
+func escapePointer(s string) string {
+	return strings.Replace(strings.Replace(s, "~", "~0", -1), "/", "~1", -1)
+}
+
+func unescapePointer(s string) string {
+	return strings.Replace(strings.Replace(s, "~1", "/", -1), "~0", "~", -1)
+}
+
+func matchType(t, value interface{}) bool {
+	switch tt := t.(type) {
+	case string:
+		actual := typeOf(value)
+		return actual == tt || (tt == "number" && actual == "integer")
+	case []interface{}:
+		for _, v := range tt {
+			if matchType(v, value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func typeNames(t interface{}) string {
+	if tt, ok := t.([]interface{}); ok {
+		names := []string{}
+		for _, v := range tt {
+			names = append(names, fmt.Sprint(v))
+		}
+		return strings.Join(names, " or ")
+	}
+	return fmt.Sprint(t)
+}
+
+func typeOf(value interface{}) string {
+	switch t := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case float64:
+		if t == math.Trunc(t) {
+			return "integer"
+		}
+		return "number"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func jsonString(v interface{}) string {
+	data, _ := json.Marshal(v)
+	return string(data)
+}
@@ -0,0 +1,287 @@
+package swagger
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// the sections of reusable objects which are referenced by `$ref`
+var refSections = []string{"definitions", "parameters", "responses"}
+
+// Service the swagger doc of a service to merge
+type Service struct {
+	Name   string      // namespace the tags and the conflicting definitions
+	Prefix string      // the prefix of paths, default is the basePath of doc
+	Doc    interface{} // the generic swagger doc
+}
+
+// MergeOption the options of merged doc
+type MergeOption struct {
+	Title     string
+	Version   string
+	BasePath  string
+	TagPrefix bool // prefix the tags with the names of services
+}
+
+// merger merge the swagger docs of services
+type merger struct {
+	opt       *MergeOption
+	doc       map[string]interface{}
+	services  []*Service
+	renamed   map[string]map[string]bool // section -> name -> namespaced
+	ops       map[string]string          // operationId -> service name
+	conflicts []string
+}
+
+// Merge merge the swagger docs of services into one,
+// the identical definitions collapse and the conflicting ones are namespaced by the services,
+// returns the merged doc and the conflicts which are ignored in it
+func Merge(services []*Service, opt *MergeOption) (map[string]interface{}, []string) {
+	m := &merger{
+		opt:      opt,
+		services: services,
+		renamed:  map[string]map[string]bool{},
+		ops:      map[string]string{},
+		doc: map[string]interface{}{
+			"swagger": "2.0",
+			"info": map[string]interface{}{
+				"title":   opt.Title,
+				"version": opt.Version,
+			},
+			"paths": map[string]interface{}{},
+		},
+	}
+	if opt.BasePath != "" {
+		m.doc["basePath"] = opt.BasePath
+	}
+	m.resolveConflicts()
+	for _, section := range refSections {
+		m.section(section)
+	}
+	for _, s := range services {
+		doc, _ := s.Doc.(map[string]interface{})
+		m.paths(s, doc)
+		m.tags(s, doc)
+		m.securityDefinitions(s, doc)
+	}
+	return m.doc, m.conflicts
+}
+
+// resolveConflicts find the conflicting objects which must be namespaced,
+// the objects referencing the namespaced objects may be conflicting too
+func (m *merger) resolveConflicts() {
+	for _, section := range refSections {
+		m.renamed[section] = map[string]bool{}
+	}
+	for changed := true; changed; {
+		changed = false
+		for _, section := range refSections {
+			for _, name := range m.sharedNames(section) {
+				if m.renamed[section][name] {
+					continue
+				}
+				var first interface{}
+				for _, s := range m.services {
+					obj, ok := m.object(s, section, name)
+					if !ok {
+						continue
+					}
+					obj = m.rewriteRefs(s, obj)
+					if first == nil {
+						first = obj
+					} else if !reflect.DeepEqual(first, obj) {
+						m.renamed[section][name] = true
+						changed = true
+						break
+					}
+				}
+			}
+		}
+	}
+}
+
+// sharedNames the names of objects in the section which are defined by more than one service
+func (m *merger) sharedNames(section string) []string {
+	count := map[string]int{}
+	names := []string{}
+	for _, s := range m.services {
+		doc, _ := s.Doc.(map[string]interface{})
+		objs, _ := doc[section].(map[string]interface{})
+		for name := range objs {
+			if count[name]++; count[name] == 2 {
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (m *merger) object(s *Service, section, name string) (interface{}, bool) {
+	doc, _ := s.Doc.(map[string]interface{})
+	objs, _ := doc[section].(map[string]interface{})
+	obj, ok := objs[name]
+	return obj, ok
+}
+
+// name the name of object in merged doc
+func (m *merger) name(s *Service, section, name string) string {
+	if m.renamed[section][name] {
+		return s.Name + "." + name
+	}
+	return name
+}
+
+// rewriteRefs copy the value and point the `$ref`s to the objects in merged doc
+func (m *merger) rewriteRefs(s *Service, v interface{}) interface{} {
+	return RewriteRefs(v, func(ref string) string {
+		for _, section := range refSections {
+			prefix := "#/" + section + "/"
+			if strings.HasPrefix(ref, prefix) {
+				name := unescapePointer(strings.TrimPrefix(ref, prefix))
+				return prefix + escapePointer(m.name(s, section, name))
+			}
+		}
+		return ref
+	})
+}
+
+func (m *merger) section(section string) {
+	objs := map[string]interface{}{}
+	for _, s := range m.services {
+		doc, _ := s.Doc.(map[string]interface{})
+		sobjs, _ := doc[section].(map[string]interface{})
+		for _, name := range sortedKeys(sobjs) {
+			obj := m.rewriteRefs(s, sobjs[name])
+			name = m.name(s, section, name)
+			// the namespaced name may be the name of another service's object
+			if existed, ok := objs[name]; ok && !reflect.DeepEqual(existed, obj) {
+				m.conflicts = append(m.conflicts, fmt.Sprintf("%s(%s) of service(%s) is ignored, it has been defined", section, name, s.Name))
+				continue
+			}
+			objs[name] = obj
+		}
+	}
+	if len(objs) != 0 {
+		m.doc[section] = objs
+	}
+}
+
+func (m *merger) paths(s *Service, doc map[string]interface{}) {
+	prefix := s.Prefix
+	if prefix == "" {
+		prefix, _ = doc["basePath"].(string)
+	}
+	prefix = "/" + strings.Trim(prefix, "/")
+	if prefix == "/" {
+		prefix = ""
+	}
+	paths := m.doc["paths"].(map[string]interface{})
+	spaths, _ := doc["paths"].(map[string]interface{})
+	for _, path := range sortedKeys(spaths) {
+		sitem, _ := spaths[path].(map[string]interface{})
+		fullPath := prefix + path
+		item, ok := paths[fullPath].(map[string]interface{})
+		if !ok {
+			item = map[string]interface{}{}
+			paths[fullPath] = item
+		}
+		for _, key := range sortedKeys(sitem) {
+			if _, ok := item[key]; ok {
+				m.conflicts = append(m.conflicts, fmt.Sprintf("path(%s) %s of service(%s) is ignored, it has been defined", fullPath, key, s.Name))
+				continue
+			}
+			v := m.rewriteRefs(s, sitem[key])
+			if op, ok := v.(map[string]interface{}); ok && isMethod(key) {
+				m.operation(s, doc, op)
+			}
+			item[key] = v
+		}
+	}
+}
+
+// operation namespace the tags and operationId and
+// inherit the consumes, produces, schemes and security of service doc
+func (m *merger) operation(s *Service, doc map[string]interface{}, op map[string]interface{}) {
+	if m.opt.TagPrefix {
+		tags := []interface{}{}
+		for _, tag := range stringList(op["tags"]) {
+			tags = append(tags, s.Name+"."+tag)
+		}
+		if len(tags) != 0 {
+			op["tags"] = tags
+		}
+	}
+	if id, ok := op["operationId"].(string); ok {
+		if name, ok := m.ops[id]; ok && name != s.Name {
+			id = s.Name + "." + id
+			op["operationId"] = id
+			if name, ok := m.ops[id]; ok && name != s.Name {
+				m.conflicts = append(m.conflicts, fmt.Sprintf("operationId(%s) of service(%s) is duplicated, it has been defined by service(%s)", id, s.Name, name))
+			}
+		}
+		m.ops[id] = s.Name
+	}
+	for _, key := range []string{"consumes", "produces", "schemes", "security"} {
+		if _, ok := op[key]; !ok && doc[key] != nil {
+			op[key] = doc[key]
+		}
+	}
+}
+
+func (m *merger) tags(s *Service, doc map[string]interface{}) {
+	tags, _ := m.doc["tags"].([]interface{})
+	seen := map[string]bool{}
+	for _, tag := range tags {
+		name, _ := tag.(map[string]interface{})["name"].(string)
+		seen[name] = true
+	}
+	stags, _ := doc["tags"].([]interface{})
+	for _, tag := range stags {
+		tag, ok := m.rewriteRefs(s, tag).(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := tag["name"].(string)
+		if m.opt.TagPrefix {
+			name = s.Name + "." + name
+			tag["name"] = name
+		}
+		if !seen[name] {
+			seen[name] = true
+			tags = append(tags, tag)
+		}
+	}
+	if len(tags) != 0 {
+		m.doc["tags"] = tags
+	}
+}
+
+func (m *merger) securityDefinitions(s *Service, doc map[string]interface{}) {
+	defs, _ := m.doc["securityDefinitions"].(map[string]interface{})
+	sdefs, _ := doc["securityDefinitions"].(map[string]interface{})
+	for _, name := range sortedKeys(sdefs) {
+		if defs == nil {
+			defs = map[string]interface{}{}
+			m.doc["securityDefinitions"] = defs
+		}
+		if def, ok := defs[name]; ok {
+			if !reflect.DeepEqual(def, sdefs[name]) {
+				m.conflicts = append(m.conflicts, fmt.Sprintf("securityDefinition(%s) of service(%s) is ignored, it has been defined", name, s.Name))
+			}
+			continue
+		}
+		defs[name] = sdefs[name]
+	}
+}
+
+func isMethod(s string) bool {
+	for _, method := range Methods {
+		if s == method {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,115 @@
+package swagger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMerge(t *testing.T) {
+	assert := assert.New(t)
+
+	users, err := Unmarshal([]byte(`
+swagger: "2.0"
+info: {title: users, version: "1.0.0"}
+basePath: /users
+produces: [application/json]
+security: [{key: []}]
+securityDefinitions:
+  key: {type: apiKey, name: key, in: header}
+tags: [{name: user}]
+paths:
+  /:
+    get:
+      tags: [user]
+      operationId: list
+      responses:
+        200: {description: OK, schema: {$ref: "#/definitions/Page"}}
+  /shared:
+    get:
+      responses:
+        200: {description: OK}
+definitions:
+  Page:
+    properties:
+      items: {type: array, items: {$ref: "#/definitions/Item"}}
+  Item:
+    properties:
+      id: {type: string}
+  Error:
+    properties:
+      message: {type: string}
+`))
+	assert.Nil(err)
+	teams, err := Unmarshal([]byte(`
+swagger: "2.0"
+info: {title: teams, version: "1.0.0"}
+basePath: /teams
+tags: [{name: team}]
+paths:
+  /:
+    get:
+      tags: [team]
+      operationId: list
+      responses:
+        200: {description: OK, schema: {$ref: "#/definitions/Page"}}
+        400: {description: Error, schema: {$ref: "#/definitions/Error"}}
+definitions:
+  Page:
+    properties:
+      items: {type: array, items: {$ref: "#/definitions/Item"}}
+  Item:
+    properties:
+      id: {type: integer}
+  Error:
+    properties:
+      message: {type: string}
+`))
+	assert.Nil(err)
+	others, err := Unmarshal([]byte(`
+swagger: "2.0"
+info: {title: others, version: "1.0.0"}
+paths:
+  /users/shared:
+    get:
+      responses:
+        200: {description: OK}
+definitions:
+  users.Item:
+    properties:
+      name: {type: string}
+`))
+	assert.Nil(err)
+
+	doc, conflicts := Merge([]*Service{
+		&Service{Name: "users", Doc: users},
+		&Service{Name: "teams", Doc: teams},
+		&Service{Name: "others", Doc: others},
+	}, &MergeOption{Title: "gateway", Version: "1.0.0", TagPrefix: true})
+	assert.Equal([]string{
+		"definitions(users.Item) of service(others) is ignored, it has been defined",
+		"path(/users/shared) get of service(others) is ignored, it has been defined",
+	}, conflicts)
+	assert.Empty(Validate(doc))
+
+	definitions := doc["definitions"].(map[string]interface{})
+	// identical
+	assert.NotNil(definitions["Error"])
+	// conflicting and the ones referencing them
+	assert.Equal(5, len(definitions))
+	assert.NotNil(definitions["users.Item"])
+	assert.NotNil(definitions["teams.Item"])
+	assert.NotNil(definitions["teams.Page"])
+
+	paths := doc["paths"].(map[string]interface{})
+	op := paths["/users/"].(map[string]interface{})["get"].(map[string]interface{})
+	assert.Equal([]interface{}{"users.user"}, op["tags"])
+	assert.Equal("list", op["operationId"])
+	assert.Equal([]interface{}{"application/json"}, op["produces"])
+	assert.Equal([]interface{}{map[string]interface{}{"key": []interface{}{}}}, op["security"])
+	assert.Equal("#/definitions/users.Page", op["responses"].(map[string]interface{})["200"].(map[string]interface{})["schema"].(map[string]interface{})["$ref"])
+	op = paths["/teams/"].(map[string]interface{})["get"].(map[string]interface{})
+	assert.Equal("teams.list", op["operationId"])
+	assert.Nil(op["security"])
+	assert.Equal(2, len(doc["tags"].([]interface{})))
+}
@@ -0,0 +1,227 @@
+package swagger
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Overlay the overlay document which patches the swagger doc by actions,
+// see https://github.com/OAI/Overlay-Specification
+type Overlay struct {
+	Actions []*OverlayAction
+}
+
+// OverlayAction update or remove the values selected by the target JSONPath
+type OverlayAction struct {
+	Target string
+	Update interface{}
+	Remove bool
+	path   jsonPath
+}
+
+// ParseOverlay parse the generic overlay document
+func ParseOverlay(doc interface{}) (*Overlay, error) {
+	m, ok := doc.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("overlay should be an object")
+	}
+	if _, ok := m["overlay"].(string); !ok {
+		return nil, fmt.Errorf("overlay should have the version of overlay specification, like `overlay: 1.0.0`")
+	}
+	actions, ok := m["actions"].([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("overlay should have the actions")
+	}
+	o := &Overlay{}
+	for i, v := range actions {
+		action, _ := v.(map[string]interface{})
+		target, _ := action["target"].(string)
+		if target == "" {
+			return nil, fmt.Errorf("the target of overlay action(%d) is missing", i)
+		}
+		path, err := compileJSONPath(target)
+		if err != nil {
+			return nil, err
+		}
+		remove, _ := action["remove"].(bool)
+		o.Actions = append(o.Actions, &OverlayAction{
+			Target: target,
+			Update: action["update"],
+			Remove: remove,
+			path:   path,
+		})
+	}
+	return o, nil
+}
+
+// Apply apply the actions to the generic swagger doc in order,
+// returns the patched doc and the targets which select nothing
+func (o *Overlay) Apply(doc interface{}) (interface{}, []string) {
+	unmatched := []string{}
+	for _, action := range o.Actions {
+		if action.path == nil {
+			path, err := compileJSONPath(action.Target)
+			if err != nil {
+				unmatched = append(unmatched, action.Target)
+				continue
+			}
+			action.path = path
+		}
+		nodes := action.path.find(doc)
+		if len(nodes) == 0 {
+			unmatched = append(unmatched, action.Target)
+			continue
+		}
+		if action.Remove {
+			doc = removeNodes(doc, nodes)
+			continue
+		}
+		if action.Update == nil {
+			continue
+		}
+		for _, n := range nodes {
+			var v interface{}
+			if list, ok := n.value.([]interface{}); ok {
+				v = append(list, copyValue(action.Update))
+			} else {
+				v = extend(n.value, action.Update, true)
+			}
+			doc = setValue(doc, n.location, v)
+		}
+	}
+	return doc, unmatched
+}
+
+// Extend deep merge the base doc under the generated doc,
+// the values of generated doc win if both of them have
+func Extend(doc, base interface{}) interface{} {
+	return extend(doc, base, false)
+}
+
+// extend deep merge the objects of src into dst,
+// src wins the other values if override
+func extend(dst, src interface{}, override bool) interface{} {
+	dm, ok1 := dst.(map[string]interface{})
+	sm, ok2 := src.(map[string]interface{})
+	if !ok1 || !ok2 {
+		if override {
+			return copyValue(src)
+		}
+		return dst
+	}
+	for k, v := range sm {
+		if dv, ok := dm[k]; ok {
+			dm[k] = extend(dv, v, override)
+		} else {
+			dm[k] = copyValue(v)
+		}
+	}
+	return dm
+}
+
+// copyValue deep copy the generic value
+func copyValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(t))
+		for k, v := range t {
+			m[k] = copyValue(v)
+		}
+		return m
+	case []interface{}:
+		l := make([]interface{}, len(t))
+		for i, v := range t {
+			l[i] = copyValue(v)
+		}
+		return l
+	}
+	return v
+}
+
+// setValue set the value at location and returns the doc
+func setValue(doc interface{}, location []interface{}, v interface{}) interface{} {
+	if len(location) == 0 {
+		return v
+	}
+	switch t := doc.(type) {
+	case map[string]interface{}:
+		k := location[0].(string)
+		t[k] = setValue(t[k], location[1:], v)
+	case []interface{}:
+		i := location[0].(int)
+		t[i] = setValue(t[i], location[1:], v)
+	}
+	return doc
+}
+
+// removeNodes remove the nodes from doc, the root can't be removed
+func removeNodes(doc interface{}, nodes []*pathNode) interface{} {
+	// the later elements of arrays and the descendants are removed first
+	sort.Slice(nodes, func(i, j int) bool {
+		return compareLocation(nodes[i].location, nodes[j].location) > 0
+	})
+	for _, n := range nodes {
+		if len(n.location) == 0 {
+			continue
+		}
+		parentLocation, key := n.location[:len(n.location)-1], n.location[len(n.location)-1]
+		parent := (&pathNode{value: doc}).at(parentLocation)
+		switch t := parent.(type) {
+		case map[string]interface{}:
+			delete(t, key.(string))
+		case []interface{}:
+			i := key.(int)
+			if i < len(t) {
+				doc = setValue(doc, parentLocation, append(t[:i:i], t[i+1:]...))
+			}
+		}
+	}
+	return doc
+}
+
+// at the value at the location
+func (n *pathNode) at(location []interface{}) interface{} {
+	v := n.value
+	for _, key := range location {
+		switch t := v.(type) {
+		case map[string]interface{}:
+			v = t[key.(string)]
+		case []interface{}:
+			i := key.(int)
+			if i >= len(t) {
+				return nil
+			}
+			v = t[i]
+		default:
+			return nil
+		}
+	}
+	return v
+}
+
+// compareLocation compare the locations element by element
+func compareLocation(a, b []interface{}) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		switch x := a[i].(type) {
+		case string:
+			y, ok := b[i].(string)
+			if !ok || x == y {
+				continue
+			}
+			if x < y {
+				return -1
+			}
+			return 1
+		case int:
+			y, ok := b[i].(int)
+			if !ok || x == y {
+				continue
+			}
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return len(a) - len(b)
+}
@@ -0,0 +1,100 @@
+package swagger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONPath(t *testing.T) {
+	assert := assert.New(t)
+
+	doc, err := Unmarshal([]byte(`
+paths:
+  /users:
+    get: {operationId: list, tags: [user, admin]}
+    post: {operationId: create, deprecated: true}
+  /teams:
+    get: {operationId: teams}
+`))
+	assert.Nil(err)
+	for expr, values := range map[string][]interface{}{
+		"$.paths['/users'].get.operationId":               {"list"},
+		`$.paths["/users"].get.tags[-1]`:                  {"admin"},
+		"$.paths.*.get.operationId":                       {"teams", "list"},
+		"$..operationId":                                  {"teams", "list", "create"},
+		"$.paths[*][?(@.deprecated == true)].operationId": {"create"},
+		"$.paths[*][?@.tags].operationId":                 {"list"},
+		"$.paths['/none']":                                {},
+	} {
+		p, err := compileJSONPath(expr)
+		assert.Nil(err, expr)
+		found := []interface{}{}
+		for _, n := range p.find(doc) {
+			found = append(found, n.value)
+		}
+		assert.Equal(values, found, expr)
+	}
+	for _, expr := range []string{"paths", "$.paths[", "$.paths[abc]", "$.paths[?(x == 1)]"} {
+		_, err := compileJSONPath(expr)
+		assert.NotNil(err, expr)
+	}
+}
+
+func TestOverlay(t *testing.T) {
+	assert := assert.New(t)
+
+	doc, err := Unmarshal([]byte(`
+info: {title: test}
+tags: [{name: user}]
+paths:
+  /users:
+    get: {operationId: list, parameters: [{name: a}, {name: b}, {name: c}]}
+    post: {operationId: create, x-internal: true}
+`))
+	assert.Nil(err)
+	base, err := Unmarshal([]byte(`
+info: {title: base, x-logo: {url: logo.png}}
+tags: [{name: base}]
+securityDefinitions:
+  token: {type: apiKey, in: header, name: Authorization}
+`))
+	assert.Nil(err)
+	doc = Extend(doc, base)
+	info := doc.(map[string]interface{})["info"].(map[string]interface{})
+	assert.Equal("test", info["title"])
+	assert.NotNil(info["x-logo"])
+	assert.Equal(1, len(doc.(map[string]interface{})["tags"].([]interface{})))
+	assert.NotNil(doc.(map[string]interface{})["securityDefinitions"])
+
+	overlayDoc, err := Unmarshal([]byte(`
+overlay: 1.0.0
+actions:
+- target: $.info
+  update: {description: hand-written}
+- target: $.tags
+  update: {name: team}
+- target: $.paths.*[?(@.x-internal == true)]
+  remove: true
+- target: $.paths['/users'].get.parameters[?(@.name != 'b')]
+  remove: true
+- target: $.definitions
+  remove: true
+`))
+	assert.Nil(err)
+	overlay, err := ParseOverlay(overlayDoc)
+	assert.Nil(err)
+	doc, unmatched := overlay.Apply(doc)
+	assert.Equal([]string{"$.definitions"}, unmatched)
+	m := doc.(map[string]interface{})
+	assert.Equal("hand-written", m["info"].(map[string]interface{})["description"])
+	assert.Equal(2, len(m["tags"].([]interface{})))
+	item := m["paths"].(map[string]interface{})["/users"].(map[string]interface{})
+	assert.Nil(item["post"])
+	assert.Equal([]interface{}{map[string]interface{}{"name": "b"}}, item["get"].(map[string]interface{})["parameters"])
+
+	_, err = ParseOverlay(map[string]interface{}{"overlay": "1.0.0", "actions": []interface{}{
+		map[string]interface{}{"target": "paths"},
+	}})
+	assert.NotNil(err)
+}
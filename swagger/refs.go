@@ -0,0 +1,158 @@
+package swagger
+
+import (
+	"sort"
+	"strings"
+)
+
+const definitionsPrefix = "#/definitions/"
+
+// collectDefinitions collect the names of definitions referenced by the value
+// and the definitions they reference, recursively
+func collectDefinitions(doc map[string]interface{}, v interface{}, names map[string]bool) {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		if ref, ok := t["$ref"].(string); ok && strings.HasPrefix(ref, definitionsPrefix) {
+			name := unescapePointer(strings.TrimPrefix(ref, definitionsPrefix))
+			if !names[name] {
+				names[name] = true
+				definitions, _ := doc["definitions"].(map[string]interface{})
+				collectDefinitions(doc, definitions[name], names)
+			}
+		}
+		for _, v := range t {
+			collectDefinitions(doc, v, names)
+		}
+	case []interface{}:
+		for _, v := range t {
+			collectDefinitions(doc, v, names)
+		}
+	}
+}
+
+// definitionTags find the tags of operations which reference the definitions
+func definitionTags(doc map[string]interface{}) map[string][]string {
+	tags := map[string]map[string]bool{}
+	paths, _ := doc["paths"].(map[string]interface{})
+	for _, item := range paths {
+		item, _ := item.(map[string]interface{})
+		for _, method := range Methods {
+			op, ok := item[method].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			names := map[string]bool{}
+			collectDefinitions(doc, item["parameters"], names)
+			collectDefinitions(doc, op, names)
+			for name := range names {
+				if tags[name] == nil {
+					tags[name] = map[string]bool{}
+				}
+				for _, tag := range stringList(op["tags"]) {
+					tags[name][tag] = true
+				}
+			}
+		}
+	}
+	r := map[string][]string{}
+	for name, set := range tags {
+		for tag := range set {
+			r[name] = append(r[name], tag)
+		}
+		sort.Strings(r[name])
+	}
+	return r
+}
+
+// PruneDefinitions remove the definitions which can't be reached from paths,
+// returns the names of removed definitions in order
+func (s *Swagger) PruneDefinitions() []string {
+	reached := map[string]bool{}
+	var visit func(ref *string)
+	visit = func(ref *string) {
+		if !strings.HasPrefix(*ref, definitionsPrefix) {
+			return
+		}
+		name := unescapePointer(strings.TrimPrefix(*ref, definitionsPrefix))
+		if !reached[name] {
+			reached[name] = true
+			s.Definitions[name].walkRefs(visit)
+		}
+	}
+	s.walkPathRefs(visit)
+
+	removed := []string{}
+	for name := range s.Definitions {
+		if !reached[name] {
+			removed = append(removed, name)
+			delete(s.Definitions, name)
+		}
+	}
+	sort.Strings(removed)
+	return removed
+}
+
+// HasExternalRefs check if the swagger object references other files
+func (s *Swagger) HasExternalRefs() bool {
+	found := false
+	s.walkRefs(func(ref *string) {
+		if IsExternalRef(*ref) {
+			found = true
+		}
+	})
+	return found
+}
+
+// walkRefs call fn with every `$ref` in the swagger object
+func (s *Swagger) walkRefs(fn func(ref *string)) {
+	s.walkPathRefs(fn)
+	for _, schema := range s.Definitions {
+		schema.walkRefs(fn)
+	}
+}
+
+// walkPathRefs call fn with every `$ref` in the paths
+func (s *Swagger) walkPathRefs(fn func(ref *string)) {
+	for _, item := range s.Paths {
+		fn(&item.Ref)
+		for _, op := range item.Operations() {
+			for _, param := range op.Parameters {
+				param.Schema.walkRefs(fn)
+			}
+			for _, resp := range op.Responses {
+				fn(&resp.Ref)
+				resp.Schema.walkRefs(fn)
+				for _, mt := range resp.Content {
+					mt.Schema.walkRefs(fn)
+				}
+			}
+		}
+	}
+}
+
+func (ss *Schema) walkRefs(fn func(ref *string)) {
+	if ss == nil {
+		return
+	}
+	fn(&ss.Ref)
+	ss.Items.walkRefs(fn)
+	for _, s := range ss.AllOf {
+		s.walkRefs(fn)
+	}
+	for _, p := range ss.Properties {
+		p.walkRefs(fn)
+	}
+	ss.AdditionalProperties.walkRefs(fn)
+}
+
+func (sp *Propertie) walkRefs(fn func(ref *string)) {
+	if sp == nil {
+		return
+	}
+	fn(&sp.Ref)
+	sp.Items.walkRefs(fn)
+	for _, p := range sp.Properties {
+		p.walkRefs(fn)
+	}
+	sp.AdditionalProperties.walkRefs(fn)
+}
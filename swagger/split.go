@@ -0,0 +1,91 @@
+package swagger
+
+import "strings"
+
+// defaultPart the part of operations without any key
+const defaultPart = "default"
+
+// SplitKeys find the keys of parts which the operation belongs to
+type SplitKeys func(path string, op *Operation) []string
+
+// ByTag split the operations by their tags
+func ByTag(path string, op *Operation) []string {
+	return op.Tags
+}
+
+// ByPathPrefix split the operations by the first segment of paths, like `/v1`
+func ByPathPrefix(path string, op *Operation) []string {
+	prefix := strings.SplitN(strings.TrimPrefix(path, "/"), "/", 2)[0]
+	if prefix == "" {
+		return nil
+	}
+	return []string{prefix}
+}
+
+// Split split the swagger doc into parts by the keys of operations,
+// every part only contains the operations belong to it and the definitions they reach
+func (s *Swagger) Split(keys SplitKeys) map[string]*Swagger {
+	parts := map[string]*Swagger{}
+	for path, item := range s.Paths {
+		for i, op := range item.operationRefs() {
+			if *op == nil {
+				continue
+			}
+			names := keys(path, *op)
+			if len(names) == 0 {
+				names = []string{defaultPart}
+			}
+			for _, name := range names {
+				part, ok := parts[name]
+				if !ok {
+					part = s.part()
+					parts[name] = part
+				}
+				partItem, ok := part.Paths[path]
+				if !ok {
+					partItem = &Item{Ref: item.Ref}
+					part.Paths[path] = partItem
+				}
+				*partItem.operationRefs()[i] = *op
+			}
+		}
+	}
+	for _, part := range parts {
+		part.PruneDefinitions()
+		part.Tags = part.usedTags(s.Tags)
+	}
+	return parts
+}
+
+// part a copy of the swagger doc without paths
+func (s *Swagger) part() *Swagger {
+	part := *s
+	part.Paths = map[string]*Item{}
+	part.Definitions = make(map[string]*Schema, len(s.Definitions))
+	for name, schema := range s.Definitions {
+		part.Definitions[name] = schema
+	}
+	return &part
+}
+
+// usedTags filter the tags which are used by operations
+func (s *Swagger) usedTags(tags []*Tag) []*Tag {
+	used := map[string]bool{}
+	for _, item := range s.Paths {
+		for _, op := range item.Operations() {
+			for _, tag := range op.Tags {
+				used[tag] = true
+			}
+		}
+	}
+	r := []*Tag{}
+	for _, tag := range tags {
+		if used[tag.Name] {
+			r = append(r, tag)
+		}
+	}
+	if len(r) == 0 {
+		return nil
+	}
+	return r
+}
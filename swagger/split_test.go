@@ -0,0 +1,58 @@
+package swagger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplit(t *testing.T) {
+	assert := assert.New(t)
+
+	s := NewV2()
+	s.Tags = []*Tag{&Tag{Name: "users"}, &Tag{Name: "teams"}}
+	s.Paths = map[string]*Item{
+		"/v1/users": &Item{
+			Get: &Operation{Tags: []string{"users"}, Responses: map[string]*Response{
+				"200": &Response{Schema: &Schema{Ref: "#/definitions/User"}},
+			}},
+			Post: &Operation{Tags: []string{"users", "teams"}},
+		},
+		"/v2/teams": &Item{
+			Get: &Operation{Tags: []string{"teams"}, Responses: map[string]*Response{
+				"200": &Response{Schema: &Schema{Ref: "#/definitions/Team"}},
+			}},
+		},
+		"/": &Item{Get: &Operation{}},
+	}
+	s.Definitions = map[string]*Schema{
+		"User": &Schema{},
+		"Team": &Schema{},
+	}
+
+	parts := s.Split(ByTag)
+	assert.Equal(3, len(parts))
+	users := parts["users"]
+	assert.Equal(1, len(users.Paths))
+	assert.NotNil(users.Paths["/v1/users"].Get)
+	assert.NotNil(users.Paths["/v1/users"].Post)
+	assert.Equal(1, len(users.Definitions))
+	assert.NotNil(users.Definitions["User"])
+	assert.Equal([]*Tag{&Tag{Name: "users"}, &Tag{Name: "teams"}}, users.Tags)
+	teams := parts["teams"]
+	assert.Equal(2, len(teams.Paths))
+	assert.Nil(teams.Paths["/v1/users"].Get)
+	assert.NotNil(teams.Paths["/v1/users"].Post)
+	assert.NotNil(teams.Definitions["Team"])
+	assert.Nil(teams.Definitions["User"])
+	assert.NotNil(parts[defaultPart].Paths["/"])
+	assert.Nil(parts[defaultPart].Tags)
+
+	parts = s.Split(ByPathPrefix)
+	assert.Equal(3, len(parts))
+	assert.Equal(1, len(parts["v1"].Paths))
+	assert.Equal(1, len(parts["v2"].Paths))
+	// the origin doc is not changed
+	assert.Equal(3, len(s.Paths))
+	assert.Equal(2, len(s.Definitions))
+}
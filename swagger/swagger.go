@@ -7,19 +7,19 @@ func NewV2() *Swagger {
 // Swagger 2.0
 // Swagger list the resource
 type Swagger struct {
-	SwaggerVersion      string               `json:"swagger,omitempty" yaml:"swagger,omitempty"`
-	Infos               Information          `json:"info" yaml:"info"`
-	Host                string               `json:"host,omitempty" yaml:"host,omitempty"`
-	BasePath            string               `json:"basePath,omitempty" yaml:"basePath,omitempty"`
-	Schemes             []string             `json:"schemes,omitempty" yaml:"schemes,omitempty"`
-	Consumes            []string             `json:"consumes,omitempty" yaml:"consumes,omitempty"`
-	Produces            []string             `json:"produces,omitempty" yaml:"produces,omitempty"`
-	Paths               map[string]*Item     `json:"paths" yaml:"paths"`
-	Definitions         map[string]*Schema   `json:"definitions,omitempty" yaml:"definitions,omitempty"`
-	SecurityDefinitions map[string]*Security `json:"securityDefinitions,omitempty" yaml:"securityDefinitions,omitempty"`
-	Security            map[string][]string  `json:"security,omitempty" yaml:"security,omitempty"`
-	Tags                []*Tag               `json:"tags,omitempty" yaml:"tags,omitempty"`
-	ExternalDocs        *ExternalDocs        `json:"externalDocs,omitempty" yaml:"externalDocs,omitempty"`
+	SwaggerVersion      string                `json:"swagger,omitempty" yaml:"swagger,omitempty"`
+	Infos               Information           `json:"info" yaml:"info"`
+	Host                string                `json:"host,omitempty" yaml:"host,omitempty"`
+	BasePath            string                `json:"basePath,omitempty" yaml:"basePath,omitempty"`
+	Schemes             []string              `json:"schemes,omitempty" yaml:"schemes,omitempty"`
+	Consumes            []string              `json:"consumes,omitempty" yaml:"consumes,omitempty"`
+	Produces            []string              `json:"produces,omitempty" yaml:"produces,omitempty"`
+	Paths               map[string]*Item      `json:"paths" yaml:"paths"`
+	Definitions         map[string]*Schema    `json:"definitions,omitempty" yaml:"definitions,omitempty"`
+	SecurityDefinitions map[string]*Security  `json:"securityDefinitions,omitempty" yaml:"securityDefinitions,omitempty"`
+	Security            []map[string][]string `json:"security,omitempty" yaml:"security,omitempty"`
+	Tags                []*Tag                `json:"tags,omitempty" yaml:"tags,omitempty"`
+	ExternalDocs        *ExternalDocs         `json:"externalDocs,omitempty" yaml:"externalDocs,omitempty"`
 }
 
 // Information Provides metadata about the API. The metadata can be used by the clients if needed.
@@ -57,6 +57,22 @@ type Item struct {
 	Patch   *Operation `json:"patch,omitempty" yaml:"patch,omitempty"`
 }
 
+// Operations the operations of path item in the order of Methods
+func (item *Item) Operations() []*Operation {
+	ops := []*Operation{}
+	for _, op := range []*Operation{item.Get, item.Put, item.Post, item.Delete, item.Options, item.Head, item.Patch} {
+		if op != nil {
+			ops = append(ops, op)
+		}
+	}
+	return ops
+}
+
+// operationRefs the pointers to operations of path item in the order of Methods
+func (item *Item) operationRefs() []**Operation {
+	return []**Operation{&item.Get, &item.Put, &item.Post, &item.Delete, &item.Options, &item.Head, &item.Patch}
+}
+
 // Operation Describes a single API operation on a path.
 type Operation struct {
 	Tags        []string             `json:"tags,omitempty" yaml:"tags,omitempty"`
@@ -73,15 +89,21 @@ type Operation struct {
 
 // Parameter Describes a single operation parameter.
 type Parameter struct {
-	In          string          `json:"in,omitempty" yaml:"in,omitempty"`
-	Name        string          `json:"name,omitempty" yaml:"name,omitempty"`
-	Description string          `json:"description,omitempty" yaml:"description,omitempty"`
-	Required    bool            `json:"required,omitempty" yaml:"required,omitempty"`
-	Schema      *Schema         `json:"schema,omitempty" yaml:"schema,omitempty"`
-	Type        string          `json:"type,omitempty" yaml:"type,omitempty"`
-	Format      string          `json:"format,omitempty" yaml:"format,omitempty"`
-	Items       *ParameterItems `json:"items,omitempty" yaml:"items,omitempty"`
-	Default     interface{}     `json:"default,omitempty" yaml:"default,omitempty"`
+	In               string          `json:"in,omitempty" yaml:"in,omitempty"`
+	Name             string          `json:"name,omitempty" yaml:"name,omitempty"`
+	Description      string          `json:"description,omitempty" yaml:"description,omitempty"`
+	Required         bool            `json:"required,omitempty" yaml:"required,omitempty"`
+	Schema           *Schema         `json:"schema,omitempty" yaml:"schema,omitempty"`
+	Type             string          `json:"type,omitempty" yaml:"type,omitempty"`
+	Format           string          `json:"format,omitempty" yaml:"format,omitempty"`
+	Items            *ParameterItems `json:"items,omitempty" yaml:"items,omitempty"`
+	Default          interface{}     `json:"default,omitempty" yaml:"default,omitempty"`
+	CollectionFormat string          `json:"collectionFormat,omitempty" yaml:"collectionFormat,omitempty"` // Valid values are "csv", "ssv", "tsv", "pipes" or "multi".
+	AllowEmptyValue  bool            `json:"allowEmptyValue,omitempty" yaml:"allowEmptyValue,omitempty"`
+	Validation       `yaml:",inline"`
+	// swagger 2.0 has no example and deprecated parameter, they're described by the extensions
+	Example    interface{} `json:"x-example,omitempty" yaml:"x-example,omitempty"`
+	Deprecated bool        `json:"x-deprecated,omitempty" yaml:"x-deprecated,omitempty"`
 }
 
 // A limited subset of JSON-Schema's items object. It is used by parameter definitions that are not located in "body".
@@ -92,6 +114,23 @@ type ParameterItems struct {
 	Items            *ParameterItems `json:"items,omitempty" yaml:"items,omitempty"` //Required if type is "array". Describes the type of items in the array.
 	CollectionFormat string          `json:"collectionFormat,omitempty" yaml:"collectionFormat,omitempty"`
 	Default          string          `json:"default,omitempty" yaml:"default,omitempty"`
+	Validation       `yaml:",inline"`
+}
+
+// Validation the validation keywords of JSON-Schema shared by schemas, properties, parameters and items
+type Validation struct {
+	Maximum          *float64      `json:"maximum,omitempty" yaml:"maximum,omitempty"`
+	ExclusiveMaximum bool          `json:"exclusiveMaximum,omitempty" yaml:"exclusiveMaximum,omitempty"`
+	Minimum          *float64      `json:"minimum,omitempty" yaml:"minimum,omitempty"`
+	ExclusiveMinimum bool          `json:"exclusiveMinimum,omitempty" yaml:"exclusiveMinimum,omitempty"`
+	MultipleOf       *float64      `json:"multipleOf,omitempty" yaml:"multipleOf,omitempty"`
+	MaxLength        *int64        `json:"maxLength,omitempty" yaml:"maxLength,omitempty"`
+	MinLength        *int64        `json:"minLength,omitempty" yaml:"minLength,omitempty"`
+	Pattern          string        `json:"pattern,omitempty" yaml:"pattern,omitempty"`
+	MaxItems         *int64        `json:"maxItems,omitempty" yaml:"maxItems,omitempty"`
+	MinItems         *int64        `json:"minItems,omitempty" yaml:"minItems,omitempty"`
+	UniqueItems      bool          `json:"uniqueItems,omitempty" yaml:"uniqueItems,omitempty"`
+	Enum             []interface{} `json:"enum,omitempty" yaml:"enum,omitempty"`
 }
 
 // Schema Object allows the definition of input and output data types.
@@ -107,6 +146,7 @@ type Schema struct {
 	AllOf                []*Schema             `json:"allOf,omitempty" yaml:"allOf,omitempty"`
 	Properties           map[string]*Propertie `json:"properties,omitempty" yaml:"properties,omitempty"`
 	AdditionalProperties *Propertie            `json:"additionalProperties,omitempty" yaml:"additionalProperties,omitempty"`
+	Validation           `yaml:",inline"`
 }
 
 // Propertie are taken from the JSON Schema definition but their definitions were adjusted to the Swagger Specification
@@ -116,13 +156,16 @@ type Propertie struct {
 	Description          string                `json:"description,omitempty" yaml:"description,omitempty"`
 	Default              interface{}           `json:"default,omitempty" yaml:"default,omitempty"`
 	Type                 string                `json:"type,omitempty" yaml:"type,omitempty"`
-	Example              string                `json:"example,omitempty" yaml:"example,omitempty"`
+	Example              interface{}           `json:"example,omitempty" yaml:"example,omitempty"`
 	Required             []string              `json:"required,omitempty" yaml:"required,omitempty"`
 	Format               string                `json:"format,omitempty" yaml:"format,omitempty"`
 	ReadOnly             bool                  `json:"readOnly,omitempty" yaml:"readOnly,omitempty"`
 	Properties           map[string]*Propertie `json:"properties,omitempty" yaml:"properties,omitempty"`
 	Items                *Propertie            `json:"items,omitempty" yaml:"items,omitempty"`
 	AdditionalProperties *Propertie            `json:"additionalProperties,omitempty" yaml:"additionalProperties,omitempty"`
+	Validation           `yaml:",inline"`
+	// swagger 2.0 has no deprecated schema, it's described by the extension
+	Deprecated bool `json:"x-deprecated,omitempty" yaml:"x-deprecated,omitempty"`
 }
 
 // Response as they are returned from executing this operation.
@@ -130,6 +173,14 @@ type Response struct {
 	Description string  `json:"description" yaml:"description"`
 	Schema      *Schema `json:"schema,omitempty" yaml:"schema,omitempty"`
 	Ref         string  `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+	// swagger 2.0 has one schema per response, the representations of media types
+	// are described by the extension like the content of openapi 3
+	Content map[string]*MediaType `json:"x-content,omitempty" yaml:"x-content,omitempty"`
+}
+
+// MediaType the representation of response in a media type
+type MediaType struct {
+	Schema *Schema `json:"schema,omitempty" yaml:"schema,omitempty"`
 }
 
 // Security Allows the definition of a security scheme that can be used by the operations
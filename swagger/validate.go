@@ -0,0 +1,190 @@
+package swagger
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ValidationError a problem found in the swagger document
+type ValidationError struct {
+	Path    string // JSON pointer of the invalid value
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// Methods the http methods which can be described by a path item
+var Methods = []string{"get", "put", "post", "delete", "options", "head", "patch"}
+
+var (
+	pathParamRegexp = regexp.MustCompile(`\{([^{}/]+)\}`)
+	schemaChecker   *schemaValidator
+)
+
+func init() {
+	var err error
+	schemaChecker, err = newSchemaValidator(map[string]string{
+		swagger20SchemaID:   swagger20Schema,
+		jsonSchemaDraft04ID: jsonSchemaDraft04,
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Validate check the swagger document(decoded by Unmarshal) against
+// the Swagger 2.0 schema and the semantic rules which the schema can't express:
+//   - `$ref`s must be resolvable
+//   - operationIds must be unique
+//   - path params must be declared both in the path and in the parameters
+//   - file params must be in formData with form consumes
+//   - response descriptions must not be empty
+func Validate(doc interface{}) []*ValidationError {
+	errs := schemaChecker.validate(swagger20SchemaID, doc)
+	root, ok := doc.(map[string]interface{})
+	if !ok {
+		return errs
+	}
+	errs = append(errs, checkRefs(root, root, "#")...)
+	errs = append(errs, checkOperations(root)...)
+	return errs
+}
+
+// ValidateSchema validate the generic value against the Schema Object of Swagger 2.0
+func ValidateSchema(v interface{}) []*ValidationError {
+	errs := []*ValidationError{}
+	schema, _ := lookupPointer(schemaChecker.roots[swagger20SchemaID], "#/definitions/schema")
+	schemaChecker.check(swagger20SchemaID, schema, v, "#", &errs)
+	return errs
+}
+
+// checkRefs check all the local `$ref`s can be resolved
+// the external references are ignored
+func checkRefs(root map[string]interface{}, v interface{}, path string) (errs []*ValidationError) {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		if ref, ok := t["$ref"].(string); ok && strings.HasPrefix(ref, "#") {
+			if _, ok := lookupPointer(root, ref); !ok {
+				errs = append(errs, &ValidationError{path, fmt.Sprintf("unresolvable reference(%s)", ref)})
+			}
+		}
+		for _, k := range sortedKeys(t) {
+			errs = append(errs, checkRefs(root, t[k], path+"/"+escapePointer(k))...)
+		}
+	case []interface{}:
+		for i, item := range t {
+			errs = append(errs, checkRefs(root, item, fmt.Sprintf("%s/%d", path, i))...)
+		}
+	}
+	return
+}
+
+func checkOperations(root map[string]interface{}) (errs []*ValidationError) {
+	paths, _ := root["paths"].(map[string]interface{})
+	operationIDs := map[string]string{}
+	for _, p := range sortedKeys(paths) {
+		item, ok := paths[p].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		itemPath := "#/paths/" + escapePointer(p)
+		for _, method := range Methods {
+			op, ok := item[method].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			opPath := itemPath + "/" + method
+			report := func(format string, args ...interface{}) {
+				errs = append(errs, &ValidationError{opPath, fmt.Sprintf(format, args...)})
+			}
+
+			if id, ok := op["operationId"].(string); ok {
+				if existed, ok := operationIDs[id]; ok {
+					report("operationId(%s) has existed in %s", id, existed)
+				} else {
+					operationIDs[id] = opPath
+				}
+			}
+
+			// operation's parameters override the path item's
+			params := map[string]map[string]interface{}{}
+			for _, list := range []interface{}{item["parameters"], op["parameters"]} {
+				items, _ := list.([]interface{})
+				for _, v := range items {
+					param, ok := v.(map[string]interface{})
+					if !ok {
+						continue
+					}
+					if ref, ok := param["$ref"].(string); ok {
+						resolved, _ := lookupPointer(root, ref)
+						if param, ok = resolved.(map[string]interface{}); !ok {
+							continue
+						}
+					}
+					params[fmt.Sprint(param["in"], ":", param["name"])] = param
+				}
+			}
+
+			declared := map[string]bool{}
+			for _, m := range pathParamRegexp.FindAllStringSubmatch(p, -1) {
+				declared[m[1]] = true
+				if _, ok := params["path:"+m[1]]; !ok {
+					report("path param(%s) is not declared in parameters", m[1])
+				}
+			}
+			hasFile := false
+			for _, key := range sortedParamKeys(params) {
+				param := params[key]
+				switch {
+				case param["in"] == "path" && !declared[fmt.Sprint(param["name"])]:
+					report("path param(%v) doesn't appear in path(%s)", param["name"], p)
+				case param["type"] == "file":
+					hasFile = true
+					if param["in"] != "formData" {
+						report("file param(%v) must be in formData", param["name"])
+					}
+				}
+			}
+			if hasFile {
+				consumes, ok := op["consumes"].([]interface{})
+				if !ok {
+					consumes, _ = root["consumes"].([]interface{})
+				}
+				for _, c := range consumes {
+					if c != "multipart/form-data" && c != "application/x-www-form-urlencoded" {
+						report("file param existed and consumes%v must in(multipart/form-data, application/x-www-form-urlencoded)", consumes)
+						break
+					}
+				}
+			}
+
+			responses, _ := op["responses"].(map[string]interface{})
+			for _, code := range sortedKeys(responses) {
+				resp, ok := responses[code].(map[string]interface{})
+				if !ok {
+					continue
+				}
+				if _, isRef := resp["$ref"]; isRef {
+					continue
+				}
+				if desc, _ := resp["description"].(string); strings.TrimSpace(desc) == "" {
+					errs = append(errs, &ValidationError{opPath + "/responses/" + code, "response description should not be empty"})
+				}
+			}
+		}
+	}
+	return
+}
+
+func sortedParamKeys(m map[string]map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,74 @@
+package swagger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	doc, err := Unmarshal([]byte(`
+swagger: "2.0"
+info: {title: test, version: "1.0.0"}
+paths:
+  /users/{id}:
+    get:
+      operationId: getUser
+      parameters:
+      - {name: id, in: path, type: string, required: true}
+      responses:
+        200: {description: OK, schema: {$ref: "#/definitions/User"}}
+definitions:
+  User: {type: object}
+`))
+	assert.Nil(err)
+	assert.Empty(Validate(doc))
+
+	doc, err = Unmarshal([]byte(`{
+	"swagger": "2.0",
+	"info": {"title": "test", "version": "1.0.0"},
+	"security": {"key": []},
+	"paths": {
+		"/users/{id}": {
+			"get": {
+				"operationId": "getUser",
+				"parameters": [{"name": "avatar", "in": "query", "type": "file"}],
+				"responses": {"200": {"description": "", "schema": {"$ref": "#/definitions/User"}}}
+			},
+			"put": {
+				"operationId": "getUser",
+				"parameters": [{"name": "id", "in": "path", "type": "string", "required": true}],
+				"responses": {"200": {"description": "OK"}}
+			}
+		}
+	}
+}`))
+	assert.Nil(err)
+	messages := []string{}
+	for _, e := range Validate(doc) {
+		messages = append(messages, e.Error())
+	}
+	assert.Contains(messages, `#/paths/~1users~1{id}/get/parameters/0/in: should be one of ["formData"]`)
+	assert.Contains(messages, "#/security: should be array, not object")
+	assert.Contains(messages, "#/paths/~1users~1{id}/get/responses/200/schema: unresolvable reference(#/definitions/User)")
+	assert.Contains(messages, "#/paths/~1users~1{id}/get: path param(id) is not declared in parameters")
+	assert.Contains(messages, "#/paths/~1users~1{id}/get: file param(avatar) must be in formData")
+	assert.Contains(messages, "#/paths/~1users~1{id}/get/responses/200: response description should not be empty")
+	assert.Contains(messages, "#/paths/~1users~1{id}/put: operationId(getUser) has existed in #/paths/~1users~1{id}/get")
+}
+
+func TestValidateConcurrently(t *testing.T) {
+	assert := assert.New(t)
+
+	doc, err := Unmarshal([]byte(`{"swagger": "2.0", "info": {"title": "test", "version": "1.0.0"}, "paths": {}, "x-a": 1}`))
+	assert.Nil(err)
+	done := make(chan []*ValidationError)
+	for i := 0; i < 8; i++ {
+		go func() { done <- Validate(doc) }()
+	}
+	for i := 0; i < 8; i++ {
+		assert.Empty(<-done)
+	}
+}
@@ -76,23 +76,28 @@ func (c *Context) GetStringByInt(rw web.ResponseWriter, req *web.Request) {
 
 // @Title GetStructByInt
 // @Summary get struct by ID
-// @Description get struct by ID
+// @Description file(docs/get-struct-by-int.md)
 // @Consumes json
-// @Produces json
+// @Produces json, text/csv
 // @Param some_id path int true "Some ID"
 // @Param offset query int true "Offset"
 // @Param limit query int true "Limit"
-// @Success 200  StructureWithEmbededStructure "Success"
-// @Failure 400  APIError "We need ID!!"
+// @Success 200  StructureWithEmbededStructure "Success" json
+// @Success 200  string "The struct in CSV" text/csv
+// @Failure 400  APIError "We need ID!!" application/problem+json
 // @Failure 404  APIError "Can not find ID"
 // @Router GET /testapi/get-struct-by-int/{some_id}
 func (c *Context) GetStructByInt(rw web.ResponseWriter, req *web.Request) {
 	c.WriteResponse(StructureWithEmbededStructure{})
 }
 
+// GetStruct2ByInt get struct2 by ID. The struct2 has
+// an *embedded* pointer.
+//
+// - the offset starts from 0
+// - the limit is 10 at most
+//
 // @Title GetStruct2ByInt
-// @Summary get struct2 by ID
-// @Description get struct2 by ID
 // @Consumes json
 // @Produces json
 // @Param some_id path int true "Some ID"
@@ -111,9 +116,11 @@ func (c *Context) GetStruct2ByInt(rw web.ResponseWriter, req *web.Request) {
 // @Description get simple array by ID
 // @Consumes json
 // @Produces json
-// @Param some_id path string true "Some ID"
+// @Param some_id path string true "Some ID" pattern(^[a-z]+$)
 // @Param offset query int true "Offset"
-// @Param limit query int true "Limit"
+// @Param limit query int true "Limit" minimum(1) maximum(100) example(10)
+// @Param order query string false "Order" asc enum(asc,desc) deprecated
+// @Param ids query []int false "IDs" collectionFormat(multi) enum(1,2,3) maxItems(10) allowEmptyValue
 // @Success 200 []string "Success"
 // @Failure 400 APIError "We need ID!!"
 // @Failure 404 APIError "Can not find ID"
@@ -127,7 +134,7 @@ func (c *Context) GetSimpleArrayByString(rw web.ResponseWriter, req *web.Request
 // @Description get struct array by ID
 // @Consumes json
 // @Produces json
-// @Param some_id path string true "Some ID" "hello world"
+// @Param - path StructPath
 // @Param body body subpackage.SimpleStructure true
 // @Param limit query int true "Limit"
 // @Success 200 []subpackage.SimpleStructure "Success"
@@ -172,6 +179,7 @@ func (c *Context) GetSameStructArraryByString(rw web.ResponseWriter, req *web.Re
 // @Success 201 SimpleStructure "Success"
 // @Failure 400 APIError "We need ID!!"
 // @Failure 404 APIError "Can not find ID"
+// @Audience internal
 // @Router DELETE /testapi/get-struct3
 func (c *Context) DelStruct3(rw web.ResponseWriter, req *web.Request) {
 	c.WriteResponse(StructureWithSlice{})
@@ -182,11 +190,16 @@ func (c *Context) DelStruct3(rw web.ResponseWriter, req *web.Request) {
 // @Description get struct3
 // @Consumes json
 // @Produces json
+// @Param body body ValidatedStructure true "Body"
+// @Param - query ListFilter
+// @Param - header RequestHeader
 // @Success 204 - "null"
 // @Success 200 StructureWithSlice "Success"
 // @Success 201 TypeInterface "Success"
+// @Success 202 JSONStructure "Success"
 // @Failure 400 APIError "We need ID!!"
 // @Failure 404 APIError "Can not find ID"
+// @Failure 500 $ref:common.yaml#/definitions/Error "Server Error"
 // @Router POST /testapi/get-struct3
 func (c *Context) PostStruct3(rw web.ResponseWriter, req *web.Request) {
 	c.WriteResponse(StructureWithSlice{})
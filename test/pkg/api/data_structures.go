@@ -1,6 +1,10 @@
 package api
 
 import (
+	"database/sql"
+	"encoding/json"
+	"net"
+	"net/url"
 	"time"
 
 	"github.com/teambition/swaggo/test/pkg/api/subpackage"
@@ -30,7 +34,7 @@ type SimpleStructureWithAnnotations struct {
 
 type StructureWithSlice struct {
 	Id   int
-	Name []byte
+	Name []byte // @Audience internal,partner
 }
 
 // hello
@@ -51,7 +55,120 @@ type StructureWithAnonymousStructure struct {
 	}
 }
 
+// APIError the error of api
 type APIError struct {
+	// the code of error,
+	// see the documents of errors
 	ErrorCode    int
-	ErrorMessage string
+	ErrorMessage string // the message of error
+}
+
+type JSONBase struct {
+	ID      string `json:"id"`
+	Name    string
+	Shadow  int
+	Tagged  int `json:"Tagged"`
+	Unknown int
+}
+
+type JSONOther struct {
+	Tagged  string
+	Unknown string
+}
+
+type JSONStructure struct {
+	JSONBase
+	*JSONOther
+	TypeString
+	hidden string
+	X, Y   int
+	Count  int64  `json:"count,string"`
+	Memo   string `json:"memo,omitempty" swaggo:"true"`
+	Shadow string
+
+	Created Timestamp
+	Level   Level
+	Raw     RawObject
+	Timeout time.Duration
+	Extra   json.RawMessage
+	IP      net.IP
+	URL     url.URL
+	Null    sql.NullString
+	Owner   subpackage.ObjectID
+}
+
+type ValidatedStructure struct {
+	Name   string   `json:"name" validate:"required,min=1,max=100"`
+	Email  string   `json:"email" binding:"required,email"`
+	Color  string   `json:"color" validate:"oneof=red green 'light blue'"`
+	Age    int      `json:"age" validate:"gte=0,lt=150"`
+	Count  int      `json:"count,string" validate:"oneof=1 2 3,min=1"`
+	Code   string   `json:"code" validate:"len=6,numeric"`
+	Tags   []string `json:"tags" validate:"min=1,unique,dive,max=10"`
+	Remark string   `json:"remark,omitempty" validate:"omitempty,max=255"`
+
+	Role   string        `json:"role" validate:"eq=admin"`
+	Pair   []int         `json:"pair" validate:"eq=2"`
+	TTL    time.Duration `json:"ttl" validate:"min=1h"`
+	Expire time.Time     `json:"expire" validate:"gt"`
+
+	ID       string  `json:"_id" swaggo:"required;desc=the id, in uuid;format=uuid;example=0b6fa3d4;readonly"`
+	Score    float64 `json:"score" swaggo:"title=Score;min=0;max=10;exclusiveMaximum;multipleOf=0.5;default=5;example=7.5"`
+	Legacy   string  `json:"legacy" validate:"oneof=a b c" swaggo:"deprecated;enum=a|b;desc=legacy\\; use name"`
+	Nickname string  `json:"nickname" validate:"required" swaggo:"desc=the nickname"`
+}
+
+// ListFilter the filter of list
+type ListFilter struct {
+	Pagination
+	// the keyword to search
+	Keyword string    `form:"q" binding:"max=50"`
+	Status  []string  `form:"status" validate:"dive,oneof=active archived"`
+	Sort    string    `query:"sort" swaggo:"default=created;enum=created|updated"`
+	Owner   *Owner    `form:"owner"`
+	Team    Owner     `form:"team" binding:"required"`
+	Secret  string    `form:"-"`
+	Cursor  string    `json:"-" form:"cursor" binding:"required"`
+	Created time.Time `json:"created"`
+	hidden  string
+}
+
+type Pagination struct {
+	Offset int `form:"offset" binding:"min=0"`
+	Limit  int `form:"limit" binding:"required,max=100"`
+}
+
+type Owner struct {
+	ID   string `form:"id" binding:"required"`
+	Name string
+}
+
+// StructPath the path params of struct
+type StructPath struct {
+	// Some ID
+	SomeID string `uri:"some_id"`
+}
+
+type RequestHeader struct {
+	RequestID string `header:"X-Request-Id" binding:"required"`
+}
+
+// Timestamp the unix timestamp in json
+// @swaggertype integer,int64
+type Timestamp struct {
+	time.Time
+}
+
+type Level int
+
+func (l Level) MarshalText() ([]byte, error) {
+	return []byte("info"), nil
+}
+
+type RawObject struct {
+	data []byte
+}
+
+func (o *RawObject) MarshalJSON() ([]byte, error) {
+	return o.data, nil
 }
@@ -1,6 +1,10 @@
 package subpackage
 
+// @name SubSimpleStructure
 type SimpleStructure struct {
 	Id   int    `json:"id" swaggo:"true,the user id,2"`
 	Name string `json:"name" swaggo:",the user name,John Smith"`
 }
+
+// ObjectID the id of document
+type ObjectID [12]byte